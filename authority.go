@@ -1,7 +1,6 @@
 package xddr
 
 import (
-	"fmt"
 	"strconv"
 	"strings"
 )
@@ -88,13 +87,13 @@ func (v Authority) Sanitize() (Authority, error) {
 	if host == "" {
 		// port only
 	} else if host[0] == '[' {
-		w, err := IPv6(host[1 : len(host)-1]).Sanitize()
+		w, err := IPv6(host).Sanitize()
 		if err != nil {
 			return "", errPosF(pos+1+posOf(err), "invalid IPv6 address: %w", err)
 		}
 
 		pos += len(host) + 1
-		host = fmt.Sprintf("[%s]", string(w))
+		host = string(hostOfIPv6(w))
 	} else if w, err := IPv4(host).Sanitize(); err == nil {
 		pos += len(host)
 		host = string(w)
@@ -77,6 +77,16 @@ func TestAuthority(t *testing.T) {
 				"user:pass@host:80",
 				"user:pass", "host", 80,
 			},
+			{
+				"[fe80::1%25eth0]:80",
+				"[fe80::1%25eth0]:80",
+				"", "[fe80::1%25eth0]", 80,
+			},
+			{
+				"[fe80::1%eth0]:80",
+				"[fe80::1%25eth0]:80",
+				"", "[fe80::1%25eth0]", 80,
+			},
 		} {
 			t.Run(string(tc.given), func(t *testing.T) {
 				v, err := tc.given.Sanitize()
@@ -84,6 +94,7 @@ func TestAuthority(t *testing.T) {
 				AssertEq(t, v.Userinfo(), tc.userinfo)
 				AssertEq(t, v.Host(), tc.host)
 				AssertEq(t, v.Port(), tc.port)
+				AssertEq(t, v, tc.normalized)
 			})
 		}
 		for _, tc := range [][]string{
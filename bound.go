@@ -0,0 +1,104 @@
+package xddr
+
+import (
+	"errors"
+	"net"
+	"strconv"
+)
+
+// LocalFromAddr returns the local address of the address of a listener or a connection.
+// TCP and UDP addresses are given as sanitized [TCPLocal] and [UDPLocal],
+// and unix addresses are given as [UnixLocal].
+//
+// Examples:
+//
+//	127.0.0.1:8080 over TCP -> tcp4:127.0.0.1:8080
+//	[::1]:53 over UDP       -> udp6:[::1]:53
+//	/run/app.sock           -> unix:/run/app.sock
+func LocalFromAddr(addr net.Addr) (Local, error) {
+	switch a := addr.(type) {
+	case nil:
+		return "", errors.New("nil address")
+	case *net.TCPAddr:
+		return localFromIP("tcp", a.IP, a.Zone, a.Port), nil
+	case *net.UDPAddr:
+		return localFromIP("udp", a.IP, a.Zone, a.Port), nil
+	case *net.UnixAddr:
+		if a.Name == "" {
+			return "", errors.New("unnamed unix socket")
+		}
+		return Local(a.Net + ":" + a.Name), nil
+	}
+
+	network, address := addr.Network(), addr.String()
+	if network == "" {
+		return "", errors.New("address has no network")
+	}
+	return Local(network + ":" + address), nil
+}
+
+func localFromIP(network string, ip net.IP, zone string, port int) Local {
+	p := strconv.Itoa(port)
+	if ip4 := ip.To4(); ip4 != nil {
+		return Local(network + "4:" + ip4.String() + ":" + p)
+	}
+	if ip == nil {
+		return Local(network + "::" + p)
+	}
+
+	h := ip.String()
+	if zone != "" {
+		h += "%" + zone
+	}
+	return Local(network + "6:[" + h + "]:" + p)
+}
+
+// Bound is a listener with its bound local address,
+// which is concrete even if it is listening on port 0.
+type Bound struct {
+	net.Listener
+
+	// Local address the listener is bound to.
+	Local Local
+}
+
+// BoundOf returns the bound of the listener.
+func BoundOf(l net.Listener) (*Bound, error) {
+	v, err := LocalFromAddr(l.Addr())
+	if err != nil {
+		return nil, err
+	}
+	return &Bound{Listener: l, Local: v}, nil
+}
+
+// boundWith returns the bound of the listener listening on the network.
+// The unspecified IPv6 address is given for the network of both families,
+// which is kept as the network of both families.
+func boundWith(l net.Listener, network string) (*Bound, error) {
+	b, err := BoundOf(l)
+	if err != nil {
+		return nil, err
+	}
+
+	switch network {
+	case "tcp", "udp":
+	default:
+		return b, nil
+	}
+	if n, addr := b.Local.Split(); n == network+"6" {
+		if h, p, err := net.SplitHostPort(addr); err == nil && h == "::" {
+			b.Local = Local(network + "::" + p)
+		}
+	}
+	return b, nil
+}
+
+// HTTP returns the URL to connect to the bound address over HTTP as [HTTPLocal.AsURL] does.
+func (b *Bound) HTTP() HTTP {
+	return HTTPLocal(b.Local).AsURL()
+}
+
+// GRPC returns the target to connect to the bound address over gRPC as [GRPCLocal.AsURL] does.
+func (b *Bound) GRPC() GRPC {
+	return GRPCLocal(b.Local).AsURL()
+}
@@ -0,0 +1,90 @@
+package xddr_test
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestLocalFromAddr(t *testing.T) {
+	for _, tc := range []struct {
+		given net.Addr
+		want  xddr.Local
+	}{
+		{&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}, "tcp4:127.0.0.1:8080"},
+		{&net.TCPAddr{IP: net.IPv6loopback, Port: 8080}, "tcp6:[::1]:8080"},
+		{&net.TCPAddr{IP: net.IPv6unspecified, Port: 80}, "tcp6:[::]:80"},
+		{&net.TCPAddr{IP: net.ParseIP("fe80::1"), Zone: "eth0", Port: 80}, "tcp6:[fe80::1%eth0]:80"},
+		{&net.TCPAddr{Port: 80}, "tcp::80"},
+		{net.TCPAddrFromAddrPort(netip.MustParseAddrPort("[::ffff:10.0.0.1]:80")), "tcp4:10.0.0.1:80"},
+		{&net.UDPAddr{IP: net.IPv4zero, Port: 53}, "udp4:0.0.0.0:53"},
+		{&net.UDPAddr{IP: net.IPv6loopback, Port: 53}, "udp6:[::1]:53"},
+		{&net.UnixAddr{Net: "unix", Name: "/run/app.sock"}, "unix:/run/app.sock"},
+		{&net.UnixAddr{Net: "unixgram", Name: "@app"}, "unixgram:@app"},
+	} {
+		t.Run(fmt.Sprintf("LocalFromAddr(%s)=%q", tc.given, tc.want), func(t *testing.T) {
+			v, err := xddr.LocalFromAddr(tc.given)
+			AssertNoError(t, err)
+			AssertEq(t, v, tc.want)
+		})
+	}
+
+	_, err := xddr.LocalFromAddr(nil)
+	AssertErrorContains(t, err, "nil address")
+
+	_, err = xddr.LocalFromAddr(&net.UnixAddr{Net: "unix"})
+	AssertErrorContains(t, err, "unnamed unix socket")
+}
+
+func TestBound(t *testing.T) {
+	t.Run("TCP", func(t *testing.T) {
+		b, err := xddr.Listen(xddr.TCPLocal("tcp4:127.0.0.1:0"))
+		AssertNoError(t, err)
+		defer b.Close()
+
+		port := strconv.Itoa(b.Addr().(*net.TCPAddr).Port)
+		AssertEq(t, b.Local, xddr.Local("tcp4:127.0.0.1:"+port))
+		AssertEq(t, b.HTTP(), xddr.HTTP("http://127.0.0.1:"+port))
+		AssertEq(t, b.GRPC(), xddr.GRPC("dns:///127.0.0.1:"+port))
+
+		v, err := xddr.TCPLocal(b.Local).Sanitize()
+		AssertNoError(t, err)
+		AssertEq(t, xddr.Local(v), b.Local)
+	})
+	t.Run("TCP on both families", func(t *testing.T) {
+		b, err := xddr.Listen(xddr.TCPLocal("tcp::0"))
+		AssertNoError(t, err)
+		defer b.Close()
+
+		port := strconv.Itoa(b.Addr().(*net.TCPAddr).Port)
+		if b.Addr().(*net.TCPAddr).IP.To4() == nil {
+			AssertEq(t, b.Local, xddr.Local("tcp::"+port))
+		}
+		AssertEq(t, b.HTTP(), xddr.HTTP("http://127.0.0.1:"+port))
+	})
+	t.Run("Unix", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "test.sock")
+		b, err := xddr.Listen(xddr.UnixLocal("unix:" + p))
+		AssertNoError(t, err)
+		defer b.Close()
+
+		AssertEq(t, b.Local, xddr.Local("unix:"+p))
+		AssertEq(t, b.HTTP(), xddr.HTTP("http+unix://"+url.PathEscape(p)))
+		AssertEq(t, b.GRPC(), xddr.GRPC("unix://"+p))
+	})
+	t.Run("BoundOf", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		AssertNoError(t, err)
+		defer l.Close()
+
+		b, err := xddr.BoundOf(l)
+		AssertNoError(t, err)
+		AssertEq(t, b.Local, xddr.Local("tcp4:"+l.Addr().String()))
+	})
+}
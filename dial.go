@@ -0,0 +1,404 @@
+package xddr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Dialable is a set of address types which can be dialed by [Dial].
+type Dialable interface {
+	HostPort | HTTP | GRPC | Local | TCPLocal | UDPLocal | UnixLocal | TCPUnixLocal | TCPUDPLocal | GRPCLocal | HTTPLocal | VsockLocal | MemLocal
+}
+
+// Dialer connects to addresses using Happy Eyeballs Version 2 (RFC 8305).
+// Addresses of both families are resolved concurrently and
+// connection attempts to them are interleaved and staggered,
+// so a broken family does not delay the connection for long.
+//
+// The zero value is ready to use.
+type Dialer struct {
+	// Resolver to resolve domain names.
+	// [NetResolver] is used if nil.
+	Resolver Resolver
+
+	// Dialer to establish each connection attempt.
+	// The zero [net.Dialer] is used if nil.
+	Dialer *net.Dialer
+
+	// Time to wait for AAAA records after A records are received (RFC 8305 §3).
+	// Defaults to 50ms.
+	ResolutionDelay time.Duration
+
+	// Time to wait before starting the next connection attempt (RFC 8305 §5).
+	// Defaults to 250ms.
+	ConnectionAttemptDelay time.Duration
+}
+
+func (d *Dialer) resolver() Resolver {
+	if d.Resolver == nil {
+		return NetResolver{}
+	}
+	return d.Resolver
+}
+
+func (d *Dialer) dialer() *net.Dialer {
+	if d.Dialer == nil {
+		return &net.Dialer{}
+	}
+	return d.Dialer
+}
+
+func (d *Dialer) resolutionDelay() time.Duration {
+	if d.ResolutionDelay <= 0 {
+		return 50 * time.Millisecond
+	}
+	return d.ResolutionDelay
+}
+
+func (d *Dialer) connectionAttemptDelay() time.Duration {
+	if d.ConnectionAttemptDelay <= 0 {
+		return 250 * time.Millisecond
+	}
+	return d.ConnectionAttemptDelay
+}
+
+// Dial connects to the address using the zero [Dialer].
+func Dial[T Dialable](ctx context.Context, v T) (net.Conn, error) {
+	return DialWith(ctx, &Dialer{}, v)
+}
+
+// DialWith connects to the address using the dialer.
+//
+// [HostPort] and [HTTP] are dialed over TCP, except [HTTP] of "http+unix" scheme is dialed to its unix socket.
+// [GRPC] targets of "dns", "ipv4", "ipv6", "unix", "unix-abstract", "vsock", and "mem" schemes are supported.
+// Local addresses are dialed on their network.
+func DialWith[T Dialable](ctx context.Context, d *Dialer, v T) (net.Conn, error) {
+	switch v := any(v).(type) {
+	case HostPort:
+		h, port, err := v.Split()
+		if err != nil {
+			return nil, err
+		}
+		return d.dialHost(ctx, "tcp", h, port)
+
+	case HTTP:
+		if path, ok := v.SocketPath(); ok {
+			return d.DialContext(ctx, "unix", path)
+		}
+		return d.dialHost(ctx, "tcp", URL(v).Host(), v.Port())
+
+	case GRPC:
+		return d.dialGRPC(ctx, v)
+	}
+
+	network, address := Local(string(v)).Split()
+	return d.DialContext(ctx, network, address)
+}
+
+// DialContext connects to the address on the named network as [net.Dialer.DialContext] does,
+// but TCP and UDP addresses with a domain name are dialed using Happy Eyeballs.
+// The address "%<interface>:<port>" is dialed to the address of the interface,
+// and an empty or unspecified host is dialed to the loopback as [HTTPLocal.AsURL] does.
+// It can be used for the DialContext hook of [net/http.Transport].
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	network, address = netAddrOf(network, address)
+	switch network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+		if name, port, ok := cutInterface(address); ok {
+			a, err := interfaceAddress(network, name, port)
+			if err != nil {
+				return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+			}
+			return d.dialer().DialContext(ctx, network, a)
+		}
+	case "vsock":
+		return d.dialVsock(ctx, address)
+	case "mem":
+		return dialMem(ctx, address)
+	default:
+		return d.dialer().DialContext(ctx, network, address)
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return d.dialer().DialContext(ctx, network, address)
+	}
+	if h, ok := loopbackOf(network, host); ok {
+		return d.dialer().DialContext(ctx, network, net.JoinHostPort(h, port))
+	}
+	if _, err := IP(host).Sanitize(); err == nil {
+		return d.dialer().DialContext(ctx, network, address)
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		// Service name such as "http".
+		return d.dialer().DialContext(ctx, network, address)
+	}
+	return d.dialDomain(ctx, network, Domain(host), p)
+}
+
+// dialVsock connects to AF_VSOCK address in the timeout and the deadline of the underlying dialer.
+func (d *Dialer) dialVsock(ctx context.Context, address string) (net.Conn, error) {
+	a, err := parseVsockAddr(address)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: "vsock", Err: err}
+	}
+	if a.cid == vsockCIDAny {
+		a.cid = vsockCIDLocal
+	}
+
+	nd := d.dialer()
+	if nd.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, nd.Timeout)
+		defer cancel()
+	}
+	if !nd.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, nd.Deadline)
+		defer cancel()
+	}
+
+	conn, err := dialVsock(ctx, a)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: "vsock", Addr: a, Err: err}
+	}
+	return conn, nil
+}
+
+func (d *Dialer) dialHost(ctx context.Context, network string, host Host, port int) (net.Conn, error) {
+	if ip, ok := host.IP(); ok {
+		return d.dialer().DialContext(ctx, network, string(ipPortOf(ip, port)))
+	}
+
+	domain, ok := host.Domain()
+	if !ok {
+		return nil, errors.New("invalid host")
+	}
+	return d.dialDomain(ctx, network, domain, port)
+}
+
+// loopbackOf returns the loopback address for the empty or unspecified host.
+func loopbackOf(network, host string) (string, bool) {
+	v6 := network[len(network)-1] == '6'
+	switch host {
+	case "":
+		if v6 {
+			return "::1", true
+		}
+		return "127.0.0.1", true
+	case "0.0.0.0":
+		return "127.0.0.1", true
+	case "::":
+		return "::1", true
+	}
+	return "", false
+}
+
+func (d *Dialer) dialGRPC(ctx context.Context, v GRPC) (net.Conn, error) {
+	switch SchemeOf(v) {
+	case "unix", "unix-abstract", "vsock", "mem":
+		network, address := v.Dialer()
+		return d.DialContext(ctx, network, address)
+	}
+
+	hps, err := grpcEndpoints(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(hps) == 1 {
+		h, port, _ := hps[0].Split()
+		return d.dialHost(ctx, "tcp", h, port)
+	}
+
+	// Hosts are IP addresses for multiple endpoints.
+	var v6, v4 []IPPort
+	for _, hp := range hps {
+		h, port, _ := hp.Split()
+		ip, _ := h.IP()
+		if _, ok := ip.V4(); ok {
+			v4 = append(v4, ipPortOf(ip, port))
+		} else {
+			v6 = append(v6, ipPortOf(ip, port))
+		}
+	}
+	return d.race(ctx, "tcp", v6, v4, nil, 0)
+}
+
+type lookupResult struct {
+	v6  bool
+	eps []IPPort
+	err error
+}
+
+func (d *Dialer) dialDomain(ctx context.Context, network string, domain Domain, port int) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	families := []bool{true, false}
+	switch network[len(network)-1] {
+	case '4':
+		families = []bool{false}
+	case '6':
+		families = []bool{true}
+	}
+
+	lookups := make(chan lookupResult, len(families))
+	for _, v6 := range families {
+		go func() {
+			n := "ip4"
+			if v6 {
+				n = "ip6"
+			}
+
+			ips, err := d.resolver().LookupIP(ctx, n, domain)
+			if err == nil && len(ips) == 0 {
+				err = errNoSuchHost(string(domain))
+			}
+			eps := make([]IPPort, len(ips))
+			for i, ip := range ips {
+				eps[i] = ipPortOf(ip, port)
+			}
+			lookups <- lookupResult{v6, eps, err}
+		}()
+	}
+
+	return d.race(ctx, network, nil, nil, lookups, len(families))
+}
+
+type dialResult struct {
+	conn net.Conn
+	err  error
+}
+
+// race connects to the endpoints of both families as described in RFC 8305.
+// More endpoints can be delivered through lookups while connection attempts are in flight.
+func (d *Dialer) race(ctx context.Context, network string, v6, v4 []IPPort, lookups <-chan lookupResult, pending int) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Errors of connection attempts are more interesting than the ones of lookups
+	// since a lookup for one of the families commonly fails.
+	var first_err, lookup_err error
+	record := func(err error) {
+		if first_err == nil {
+			first_err = err
+		}
+	}
+	receive := func(r lookupResult) {
+		pending--
+		if r.err != nil {
+			if lookup_err == nil {
+				lookup_err = r.err
+			}
+			return
+		}
+		if r.v6 {
+			v6 = append(v6, r.eps...)
+		} else {
+			v4 = append(v4, r.eps...)
+		}
+	}
+
+	// §3: Start connecting as soon as AAAA records are received,
+	// but wait a little for them if A records come first.
+	for pending > 0 && len(v6) == 0 {
+		var timeout <-chan time.Time
+		if len(v4) > 0 {
+			timeout = time.After(d.resolutionDelay())
+		}
+
+		select {
+		case r := <-lookups:
+			receive(r)
+			continue
+		case <-timeout:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		break
+	}
+
+	results := make(chan dialResult)
+	inflight := 0
+	defer func() {
+		// Close connections established after the winner.
+		go func(n int) {
+			for ; n > 0; n-- {
+				if r := <-results; r.conn != nil {
+					r.conn.Close()
+				}
+			}
+		}(inflight)
+	}()
+
+	// §4: Interleave address families, starting with IPv6.
+	prefer_v6 := true
+	next := func() (IPPort, bool) {
+		if len(v6) == 0 && len(v4) == 0 {
+			return "", false
+		}
+
+		var ep IPPort
+		if (prefer_v6 && len(v6) > 0) || len(v4) == 0 {
+			ep, v6 = v6[0], v6[1:]
+			prefer_v6 = false
+		} else {
+			ep, v4 = v4[0], v4[1:]
+			prefer_v6 = true
+		}
+		return ep, true
+	}
+
+	timer := time.NewTimer(d.connectionAttemptDelay())
+	defer timer.Stop()
+
+	ready := true
+	for {
+		if ready {
+			if ep, ok := next(); ok {
+				ready = false
+				inflight++
+				go func() {
+					conn, err := d.dialer().DialContext(ctx, network, string(ep))
+					results <- dialResult{conn, err}
+				}()
+
+				// §5: Start the next attempt after the delay even if this one is still in flight.
+				timer.Reset(d.connectionAttemptDelay())
+			}
+		}
+		if inflight == 0 && pending == 0 && len(v6) == 0 && len(v4) == 0 {
+			if first_err == nil {
+				first_err = lookup_err
+			}
+			if first_err == nil {
+				first_err = errors.New("no address to dial")
+			}
+			return nil, first_err
+		}
+
+		select {
+		case r := <-lookups:
+			receive(r)
+			if inflight == 0 {
+				ready = true
+			}
+		case <-timer.C:
+			ready = true
+		case r := <-results:
+			inflight--
+			if r.err == nil {
+				return r.conn, nil
+			}
+			record(r.err)
+			ready = true
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package xddr_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lesomnus/xddr"
+)
+
+func listenTCP(t *testing.T, address string) (net.Listener, int) {
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Skipf("listen %s: %s", address, err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	return l, l.Addr().(*net.TCPAddr).Port
+}
+
+func remoteIP(conn net.Conn) string {
+	return conn.RemoteAddr().(*net.TCPAddr).IP.String()
+}
+
+// closedPort returns a port on which nothing is likely to listen.
+func closedPort(t *testing.T) int {
+	l, p := listenTCP(t, "127.0.0.1:0")
+	l.Close()
+	return p
+}
+
+var dualStackResolver = xddr.StaticResolver{
+	Hosts: map[xddr.Domain][]xddr.IP{
+		"dual.test": {"127.0.0.1", "::1"},
+		"v4.test":   {"127.0.0.1"},
+		"v6.test":   {"::1"},
+	},
+}
+
+func TestDial(t *testing.T) {
+	ctx := context.Background()
+	d := &xddr.Dialer{Resolver: dualStackResolver}
+
+	t.Run("prefer IPv6", func(t *testing.T) {
+		_, p := listenTCP(t, "[::1]:0")
+		listenTCP(t, fmt.Sprintf("127.0.0.1:%d", p))
+
+		conn, err := xddr.DialWith(ctx, d, xddr.HostPort(fmt.Sprintf("dual.test:%d", p)))
+		AssertNoError(t, err)
+		defer conn.Close()
+		AssertEq(t, remoteIP(conn), "::1")
+	})
+	t.Run("fallback to IPv4", func(t *testing.T) {
+		_, p := listenTCP(t, "127.0.0.1:0")
+
+		conn, err := xddr.DialWith(ctx, d, xddr.HostPort(fmt.Sprintf("dual.test:%d", p)))
+		AssertNoError(t, err)
+		defer conn.Close()
+		AssertEq(t, remoteIP(conn), "127.0.0.1")
+	})
+	t.Run("staggered attempts", func(t *testing.T) {
+		_, p := listenTCP(t, "127.0.0.1:0")
+
+		// Connection attempts to IPv6 hang until they are canceled.
+		canceled := make(chan struct{})
+		d := &xddr.Dialer{
+			Resolver:               dualStackResolver,
+			ConnectionAttemptDelay: 10 * time.Millisecond,
+			Dialer: &net.Dialer{
+				ControlContext: func(ctx context.Context, network, address string, c syscall.RawConn) error {
+					if !strings.HasPrefix(address, "[") {
+						return nil
+					}
+					<-ctx.Done()
+					close(canceled)
+					return ctx.Err()
+				},
+			},
+		}
+
+		conn, err := xddr.DialWith(ctx, d, xddr.HostPort(fmt.Sprintf("dual.test:%d", p)))
+		AssertNoError(t, err)
+		defer conn.Close()
+		AssertEq(t, remoteIP(conn), "127.0.0.1")
+
+		select {
+		case <-canceled:
+		case <-time.After(time.Second):
+			t.Fatal("IPv6 attempt is not canceled")
+		}
+	})
+	t.Run("resolution delay", func(t *testing.T) {
+		_, p := listenTCP(t, "[::1]:0")
+
+		// AAAA records arrive a little after A records.
+		d := &xddr.Dialer{
+			Resolver:        slowResolver{dualStackResolver, "ip6", 10 * time.Millisecond},
+			ResolutionDelay: time.Second,
+		}
+
+		conn, err := xddr.DialWith(ctx, d, xddr.HostPort(fmt.Sprintf("dual.test:%d", p)))
+		AssertNoError(t, err)
+		defer conn.Close()
+		AssertEq(t, remoteIP(conn), "::1")
+	})
+	t.Run("network", func(t *testing.T) {
+		_, p := listenTCP(t, "127.0.0.1:0")
+
+		conn, err := d.DialContext(ctx, "tcp4", fmt.Sprintf("dual.test:%d", p))
+		AssertNoError(t, err)
+		defer conn.Close()
+		AssertEq(t, remoteIP(conn), "127.0.0.1")
+
+		_, err = d.DialContext(ctx, "tcp6", fmt.Sprintf("v4.test:%d", p))
+		AssertErrorContains(t, err, "no such host")
+	})
+	t.Run("all failed", func(t *testing.T) {
+		p := closedPort(t)
+		_, err := xddr.DialWith(ctx, d, xddr.HostPort(fmt.Sprintf("dual.test:%d", p)))
+		AssertErrorContains(t, err, "refused")
+
+		_, err = xddr.DialWith(ctx, d, xddr.HostPort("unknown.test:80"))
+		AssertErrorContains(t, err, "no such host")
+	})
+	t.Run("HTTP", func(t *testing.T) {
+		_, p := listenTCP(t, "127.0.0.1:0")
+
+		conn, err := xddr.DialWith(ctx, d, xddr.HTTP(fmt.Sprintf("http://v4.test:%d/foo", p)))
+		AssertNoError(t, err)
+		conn.Close()
+
+		conn, err = xddr.DialWith(ctx, d, xddr.HTTP(fmt.Sprintf("http://127.0.0.1:%d", p)))
+		AssertNoError(t, err)
+		conn.Close()
+	})
+	t.Run("GRPC", func(t *testing.T) {
+		_, p := listenTCP(t, "127.0.0.1:0")
+		q := closedPort(t)
+
+		for _, given := range []string{
+			"dns:///v4.test:%d",
+			"v4.test:%d",
+			"ipv4:127.0.0.1:%d",
+		} {
+			conn, err := xddr.DialWith(ctx, d, xddr.GRPC(fmt.Sprintf(given, p)))
+			AssertNoError(t, err)
+			conn.Close()
+		}
+
+		conn, err := xddr.DialWith(ctx, d, xddr.GRPC(fmt.Sprintf("ipv4:127.0.0.1:%d,127.0.0.1:%d", q, p)))
+		AssertNoError(t, err)
+		conn.Close()
+
+		_, err = xddr.DialWith(ctx, d, xddr.GRPC("xds:///wallet.grpcwallet.io"))
+		AssertErrorContains(t, err, "cannot be resolved")
+	})
+	t.Run("Local", func(t *testing.T) {
+		_, p := listenTCP(t, "127.0.0.1:0")
+
+		conn, err := xddr.DialWith(ctx, d, xddr.TCPLocal(fmt.Sprintf("tcp:v4.test:%d", p)))
+		AssertNoError(t, err)
+		conn.Close()
+
+		conn, err = xddr.Dial(ctx, xddr.Local(fmt.Sprintf("tcp4:127.0.0.1:%d", p)))
+		AssertNoError(t, err)
+		conn.Close()
+
+		s := filepath.Join(t.TempDir(), "test.sock")
+		l, err := net.Listen("unix", s)
+		AssertNoError(t, err)
+		defer l.Close()
+
+		conn, err = xddr.Dial(ctx, xddr.UnixLocal("unix:"+s))
+		AssertNoError(t, err)
+		conn.Close()
+
+		conn, err = xddr.Dial(ctx, xddr.GRPC("unix://"+s))
+		AssertNoError(t, err)
+		conn.Close()
+
+		if runtime.GOOS == "linux" {
+			name := fmt.Sprintf("xddr-test-%d", os.Getpid())
+			l, err := xddr.Listen(xddr.UnixLocal("unix-abstract:" + name))
+			AssertNoError(t, err)
+			defer l.Close()
+
+			conn, err = xddr.Dial(ctx, xddr.UnixLocal("unix:@"+name))
+			AssertNoError(t, err)
+			conn.Close()
+
+			conn, err = xddr.Dial(ctx, xddr.GRPC("unix-abstract:"+name))
+			AssertNoError(t, err)
+			conn.Close()
+		}
+	})
+}
+
+func TestDialLocal(t *testing.T) {
+	t.Run("DialLocal", func(t *testing.T) {
+		l, p := listenTCP(t, "127.0.0.1:0")
+		defer l.Close()
+
+		for _, given := range []xddr.TCPLocal{
+			xddr.TCPLocal(fmt.Sprintf("tcp::%d", p)),
+			xddr.TCPLocal(fmt.Sprintf("tcp4::%d", p)),
+			xddr.TCPLocal(fmt.Sprintf("tcp4:0.0.0.0:%d", p)),
+			xddr.TCPLocal(fmt.Sprintf("tcp4:127.0.0.1:%d", p)),
+		} {
+			t.Run(fmt.Sprintf("DialLocal(%q)", given), func(t *testing.T) {
+				conn, err := xddr.DialLocal(given)
+				AssertNoError(t, err)
+				defer conn.Close()
+				AssertEq(t, conn.RemoteAddr().String(), l.Addr().String())
+			})
+		}
+	})
+	t.Run("Listen and Dial", func(t *testing.T) {
+		for _, given := range []xddr.GRPCLocal{
+			"tcp4::0",
+			xddr.GRPCLocal("unix:" + filepath.Join(t.TempDir(), "test.sock")),
+			"mem:test",
+		} {
+			t.Run(string(given), func(t *testing.T) {
+				b, err := xddr.Listen(given)
+				AssertNoError(t, err)
+				defer b.Close()
+
+				go func() {
+					conn, err := b.Accept()
+					if err == nil {
+						conn.Close()
+					}
+				}()
+
+				conn, err := xddr.DialLocal(xddr.GRPCLocal(b.Local))
+				AssertNoError(t, err)
+				conn.Close()
+			})
+		}
+	})
+	t.Run("DialPacket", func(t *testing.T) {
+		l, err := xddr.ListenPacket(xddr.UDPLocal("udp4:127.0.0.1:0"))
+		AssertNoError(t, err)
+		defer l.Close()
+
+		_, port, _ := net.SplitHostPort(l.LocalAddr().String())
+		conn, err := xddr.DialPacket(xddr.UDPLocal("udp4::" + port))
+		AssertNoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("hello"))
+		AssertNoError(t, err)
+
+		b := make([]byte, 16)
+		n, addr, err := l.ReadFrom(b)
+		AssertNoError(t, err)
+		AssertEq(t, string(b[:n]), "hello")
+		AssertEq(t, addr.String(), conn.LocalAddr().String())
+
+		_, err = l.WriteTo([]byte("world"), addr)
+		AssertNoError(t, err)
+		n, err = conn.Read(b)
+		AssertNoError(t, err)
+		AssertEq(t, string(b[:n]), "world")
+
+		_, err = xddr.DialPacket(xddr.TCPLocal("tcp4::" + port))
+		AssertErrorContains(t, err, "not a datagram network")
+	})
+}
+
+type slowResolver struct {
+	xddr.Resolver
+	network string
+	delay   time.Duration
+}
+
+func (r slowResolver) LookupIP(ctx context.Context, network string, host xddr.Domain) ([]xddr.IP, error) {
+	if network == r.network {
+		time.Sleep(r.delay)
+	}
+	return r.Resolver.LookupIP(ctx, network, host)
+}
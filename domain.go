@@ -8,31 +8,63 @@ import (
 
 type Domain string
 
+type domainSyntax int
+
+const (
+	// Letters, digits, and hyphens, where a label cannot start or end with a hyphen.
+	domainSyntaxDefault domainSyntax = iota
+	// domainSyntaxDefault but top-level domain cannot be all-numeric (RFC 1123 §2.1).
+	domainSyntaxHostname
+	// domainSyntaxDefault but underscores and hyphens are allowed anywhere in a label.
+	domainSyntaxDNSName
+)
+
 // Sanitize validates and normalizes the domain name so that it can be used in a URL.
 // Still, it does not conform to IDNA, UTS #46 or punycode.
 func (v Domain) Sanitize() (Domain, error) {
+	return v.sanitize(domainSyntaxDefault)
+}
+
+// SanitizeHostname is [Domain.Sanitize] but strictly follows the host name syntax of RFC 1123
+// so the top-level domain cannot be all-numeric.
+func (v Domain) SanitizeHostname() (Domain, error) {
+	return v.sanitize(domainSyntaxHostname)
+}
+
+// SanitizeDNSName is [Domain.Sanitize] but permits names which are valid in DNS
+// but not as host names, such as "_acme-challenge.example.com" or "_grpc._tcp.example.com".
+func (v Domain) SanitizeDNSName() (Domain, error) {
+	return v.sanitize(domainSyntaxDNSName)
+}
+
+func (v Domain) sanitize(syntax domainSyntax) (Domain, error) {
 	if v == "" {
 		return "", fmt.Errorf("domain cannot be empty")
 	}
+	if n := len(strings.TrimSuffix(string(v), ".")); n > 253 {
+		return "", fmt.Errorf("domain too long: must be at most 253 characters, got %d", n)
+	}
 
-	n := 0     // nth label
 	l := 0     // length of current label
 	u := false // there is uppercase letter
+	var p byte // previous character
 	for i := 0; i < len(v); i++ {
 		c := v[i]
 		if c == '.' {
 			if l == 0 && i != len(v)-1 {
 				return "", errPosF(i, "empty label")
 			}
+			if p == '-' && syntax != domainSyntaxDNSName {
+				return "", errPosF(i-1, "label cannot end with a hyphen")
+			}
 			l = 0
-			n++
+			p = c
 			continue
 		}
 		if l >= 63 {
 			return "", errPosF(i, "label too long")
 		}
 
-		// first character of a label
 		if 'a' <= c && c <= 'z' {
 			// ok
 		} else if 'A' <= c && c <= 'Z' {
@@ -40,14 +72,28 @@ func (v Domain) Sanitize() (Domain, error) {
 		} else if '0' <= c && c <= '9' {
 			// ok
 		} else if c == '-' {
-			if l == 0 {
+			if l == 0 && syntax != domainSyntaxDNSName {
 				return "", errPosF(i, "label cannot start with a hyphen")
 			}
+		} else if c == '_' && syntax == domainSyntaxDNSName {
+			// ok
 		} else {
 			return "", errPosF(i, "invalid character %q", c)
 		}
 
 		l++
+		p = c
+	}
+	if p == '-' && syntax != domainSyntaxDNSName {
+		return "", errPosF(len(v)-1, "label cannot end with a hyphen")
+	}
+	if syntax == domainSyntaxHostname {
+		for tld := range v.ReversedLabels() {
+			if strings.Trim(tld, "0123456789") == "" {
+				return "", fmt.Errorf("top-level domain cannot be all-numeric")
+			}
+			break
+		}
 	}
 	if u {
 		return Domain(strings.ToLower(string(v))), nil
@@ -56,6 +102,86 @@ func (v Domain) Sanitize() (Domain, error) {
 	return v, nil
 }
 
+// IsFQDN reports whether the domain is a fully qualified domain name,
+// i.e. an absolute name that ends with the root label.
+func (v Domain) IsFQDN() bool {
+	return strings.HasSuffix(string(v), ".")
+}
+
+// Absolute returns the domain with a trailing dot.
+func (v Domain) Absolute() Domain {
+	if v.IsFQDN() {
+		return v
+	}
+	return v + "."
+}
+
+// Relative returns the domain without a trailing dot.
+func (v Domain) Relative() Domain {
+	return Domain(strings.TrimSuffix(string(v), "."))
+}
+
 func (v Domain) Labels() iter.Seq[string] {
 	return strings.SplitSeq(string(v), ".")
 }
+
+// ReversedLabels iterates over the labels from the rightmost one.
+// Unlike [Domain.Labels], the empty root label of an absolute domain is not yielded.
+//
+// Example:
+//
+//	www.example.com. -> com, example, www
+func (v Domain) ReversedLabels() iter.Seq[string] {
+	s := strings.TrimSuffix(string(v), ".")
+	return func(yield func(string) bool) {
+		for s != "" {
+			i := strings.LastIndex(s, ".")
+			if !yield(s[i+1:]) {
+				return
+			}
+			if i < 0 {
+				return
+			}
+			s = s[:i]
+		}
+	}
+}
+
+// Parent returns the domain without the leftmost label.
+// It returns false if the domain has only one label.
+//
+// Example:
+//
+//	www.example.com  -> example.com
+//	www.example.com. -> example.com.
+//	com              -> (false)
+func (v Domain) Parent() (Domain, bool) {
+	s := string(v)
+	i := strings.Index(s, ".")
+	if i < 0 || i == len(s)-1 {
+		return "", false
+	}
+	return Domain(s[i+1:]), true
+}
+
+// IsSubdomainOf reports whether the domain is contained within the given domain
+// as defined in RFC 1034 §3.1, so a domain is a subdomain of itself.
+// Trailing dots and letter cases are ignored.
+//
+// Example:
+//
+//	example.com     of example.com -> true
+//	www.example.com of example.com -> true
+//	wwwexample.com  of example.com -> false
+func (v Domain) IsSubdomainOf(parent Domain) bool {
+	s := strings.ToLower(strings.TrimSuffix(string(v), "."))
+	p := strings.ToLower(strings.TrimSuffix(string(parent), "."))
+	if p == "" {
+		// Everything is under the root.
+		return true
+	}
+	if s == p {
+		return true
+	}
+	return strings.HasSuffix(s, "."+p)
+}
@@ -0,0 +1,81 @@
+package xddr
+
+import (
+	"errors"
+	"strings"
+)
+
+// DomainPattern represents a domain name which may have a wildcard as its leftmost label
+// as in the reference identifiers of TLS certificates (RFC 6125 §6.4.3).
+// The wildcard matches exactly one label.
+//
+// Examples:
+//
+//	example.com
+//	*.example.com
+type DomainPattern string
+
+func (v DomainPattern) Sanitize() (DomainPattern, error) {
+	s := string(v)
+	if s == "" {
+		return "", errors.New("domain pattern cannot be empty")
+	}
+
+	base, wildcard := strings.CutPrefix(s, "*.")
+	if i := strings.Index(base, "*"); i >= 0 {
+		if wildcard {
+			i += len("*.")
+		}
+		return "", errPosF(i, "wildcard is allowed only as the leftmost label")
+	}
+
+	w, err := Domain(base).Sanitize()
+	if err != nil {
+		if wildcard {
+			return "", accPosErr(err, len("*."))
+		}
+		return "", err
+	}
+	if !wildcard {
+		return DomainPattern(w), nil
+	}
+	if _, ok := w.Parent(); !ok {
+		return "", errors.New("wildcard cannot cover a top-level domain")
+	}
+
+	return DomainPattern("*." + w), nil
+}
+
+// IsWildcard reports whether the pattern has a wildcard label.
+func (v DomainPattern) IsWildcard() bool {
+	return strings.HasPrefix(string(v), "*.")
+}
+
+// Domain returns the pattern without the wildcard label.
+func (v DomainPattern) Domain() Domain {
+	return Domain(strings.TrimPrefix(string(v), "*."))
+}
+
+// Match reports whether the host matches the pattern.
+// Only a domain can match and trailing dots and letter cases are ignored.
+//
+// Example:
+//
+//	*.example.com matches a.example.com
+//	*.example.com does not match example.com
+//	*.example.com does not match a.b.example.com
+func (v DomainPattern) Match(host Host) bool {
+	d, ok := host.Domain()
+	if !ok {
+		return false
+	}
+
+	s := strings.ToLower(strings.TrimSuffix(string(d), "."))
+	p := strings.ToLower(strings.TrimSuffix(string(v.Domain()), "."))
+	if !v.IsWildcard() {
+		return s == p
+	}
+
+	label, rest, ok := strings.Cut(s, ".")
+	return ok && label != "" && rest == p
+}
@@ -0,0 +1,75 @@
+package xddr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestDomainPattern(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.DomainPattern
+			want  xddr.DomainPattern
+		}{
+			{"example.com", "example.com"},
+			{"Example.COM", "example.com"},
+			{"*.example.com", "*.example.com"},
+			{"*.Example.com.", "*.example.com."},
+		} {
+			t.Run(fmt.Sprintf("DomainPattern(%q).Sanitize()=%q", tc.given, tc.want), func(t *testing.T) {
+				v, err := tc.given.Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, tc.want)
+			})
+		}
+		for _, tc := range [][]string{
+			{"cannot be empty",
+				"",
+			},
+			{"wildcard is allowed only as the leftmost label",
+				"*",
+				"a.*.example.com",
+				"f*.example.com",
+				"*.*.example.com",
+			},
+			{"wildcard cannot cover a top-level domain",
+				"*.com",
+			},
+			{"[3]: invalid character",
+				"*.a_b.com",
+			},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("DomainPattern(%q).Sanitize() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.DomainPattern(given).Sanitize()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+	t.Run("Match", func(t *testing.T) {
+		for _, tc := range []struct {
+			pattern xddr.DomainPattern
+			host    xddr.Host
+			want    bool
+		}{
+			{"example.com", "example.com", true},
+			{"example.com", "EXAMPLE.com.", true},
+			{"example.com", "a.example.com", false},
+			{"*.example.com", "a.example.com", true},
+			{"*.example.com", "A.Example.Com", true},
+			{"*.example.com", "a.example.com.", true},
+			{"*.example.com", "example.com", false},
+			{"*.example.com", "a.b.example.com", false},
+			{"*.example.com", "aexample.com", false},
+			{"*.0.2", "192.0.2.1", false},
+			{"*.example.com", "[::1]", false},
+		} {
+			t.Run(fmt.Sprintf("DomainPattern(%q).Match(%q)=%t", tc.pattern, tc.host, tc.want), func(t *testing.T) {
+				AssertEq(t, tc.pattern.Match(tc.host), tc.want)
+			})
+		}
+	})
+}
@@ -2,6 +2,8 @@ package xddr_test
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/lesomnus/xddr"
@@ -19,6 +21,8 @@ func TestDomain(t *testing.T) {
 			"example.com.",
 			"sub.domain.example.com",
 			"xn--o70b819a.example.com",
+			xddr.Domain(strings.Repeat("a.", 126) + "a"),
+			xddr.Domain(strings.Repeat("a.", 126) + "a."),
 		} {
 			t.Run(fmt.Sprintf("Domain(%q).Sanitize()=%q", given, given), func(t *testing.T) {
 				_, err := given.Sanitize()
@@ -36,6 +40,15 @@ func TestDomain(t *testing.T) {
 			{"label too long",
 				"a-very-long-label-which-exceeds-the-maximum-length-of-sixty-three-characters.example.com",
 			},
+			{"label cannot end with a hyphen",
+				"a-",
+				"a-.",
+				"example-.com",
+				"foo.example-.com",
+			},
+			{"domain too long",
+				strings.Repeat("a.", 126) + "ab",
+			},
 			{"label cannot start with a hyphen",
 				"-",
 				"-.",
@@ -62,4 +75,137 @@ func TestDomain(t *testing.T) {
 			}
 		}
 	})
+	t.Run("SanitizeHostname", func(t *testing.T) {
+		for _, given := range []xddr.Domain{
+			"localhost",
+			"example.com",
+			"example.com.",
+			"1.example.com",
+			"123.com",
+			"xn--o70b819a.example.com",
+		} {
+			t.Run(fmt.Sprintf("Domain(%q).SanitizeHostname()=%q", given, given), func(t *testing.T) {
+				_, err := given.SanitizeHostname()
+				AssertNoError(t, err)
+			})
+		}
+		for _, tc := range [][]string{
+			{"top-level domain cannot be all-numeric",
+				"42",
+				"42.",
+				"example.42",
+				"192.0.2.1",
+			},
+			{"label cannot end with a hyphen",
+				"example-.com",
+			},
+			{"invalid character",
+				"_acme-challenge.example.com",
+			},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("Domain(%q).SanitizeHostname() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.Domain(given).SanitizeHostname()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+	t.Run("SanitizeDNSName", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.Domain
+			want  xddr.Domain
+		}{
+			{"_acme-challenge.example.com", "_acme-challenge.example.com"},
+			{"_grpc._TCP.example.com.", "_grpc._tcp.example.com."},
+			{"-foo-.example.com", "-foo-.example.com"},
+		} {
+			t.Run(fmt.Sprintf("Domain(%q).SanitizeDNSName()=%q", tc.given, tc.want), func(t *testing.T) {
+				v, err := tc.given.SanitizeDNSName()
+				AssertNoError(t, err)
+				AssertEq(t, v, tc.want)
+			})
+		}
+		for _, tc := range [][]string{
+			{"empty label",
+				"foo..com",
+			},
+			{"invalid character",
+				"foo bar.com",
+			},
+			{"domain too long",
+				strings.Repeat("_.", 128),
+			},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("Domain(%q).SanitizeDNSName() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.Domain(given).SanitizeDNSName()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+	t.Run("Absolute", func(t *testing.T) {
+		for _, tc := range [][]string{
+			{"example.com", "example.com.", "example.com"},
+			{"example.com.", "example.com.", "example.com"},
+		} {
+			t.Run(fmt.Sprintf("Domain(%q).Absolute()=%q", tc[0], tc[1]), func(t *testing.T) {
+				v := xddr.Domain(tc[0])
+				AssertEq(t, v.IsFQDN(), tc[0] == tc[1])
+				AssertEq(t, v.Absolute(), xddr.Domain(tc[1]))
+				AssertEq(t, v.Relative(), xddr.Domain(tc[2]))
+				Assert(t, v.Absolute().IsFQDN(), "want FQDN")
+				Assert(t, !v.Relative().IsFQDN(), "want not FQDN")
+			})
+		}
+	})
+	t.Run("ReversedLabels", func(t *testing.T) {
+		for _, tc := range [][]string{
+			{"com", "com"},
+			{"example.com", "com,example"},
+			{"www.example.com.", "com,example,www"},
+		} {
+			t.Run(fmt.Sprintf("Domain(%q).ReversedLabels()=%s", tc[0], tc[1]), func(t *testing.T) {
+				labels := slices.Collect(xddr.Domain(tc[0]).ReversedLabels())
+				AssertEq(t, strings.Join(labels, ","), tc[1])
+			})
+		}
+	})
+	t.Run("Parent", func(t *testing.T) {
+		for _, tc := range [][]string{
+			{"www.example.com", "example.com"},
+			{"www.example.com.", "example.com."},
+			{"example.com", "com"},
+			{"com", ""},
+			{"com.", ""},
+		} {
+			t.Run(fmt.Sprintf("Domain(%q).Parent()=%q", tc[0], tc[1]), func(t *testing.T) {
+				v, ok := xddr.Domain(tc[0]).Parent()
+				AssertEq(t, ok, tc[1] != "")
+				AssertEq(t, v, xddr.Domain(tc[1]))
+			})
+		}
+	})
+	t.Run("IsSubdomainOf", func(t *testing.T) {
+		for _, tc := range []struct {
+			given  xddr.Domain
+			parent xddr.Domain
+			want   bool
+		}{
+			{"example.com", "example.com", true},
+			{"www.example.com", "example.com", true},
+			{"a.b.example.com", "example.com", true},
+			{"www.example.com.", "example.com", true},
+			{"www.example.com", "Example.COM.", true},
+			{"www.example.com", ".", true},
+			{"wwwexample.com", "example.com", false},
+			{"example.com", "www.example.com", false},
+			{"example.org", "example.com", false},
+		} {
+			t.Run(fmt.Sprintf("Domain(%q).IsSubdomainOf(%q)=%t", tc.given, tc.parent, tc.want), func(t *testing.T) {
+				AssertEq(t, tc.given.IsSubdomainOf(tc.parent), tc.want)
+			})
+		}
+	})
 }
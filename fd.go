@@ -0,0 +1,211 @@
+package xddr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FDLocal represents a socket inherited as an open file descriptor,
+// such as the one passed by the socket activation of systemd.
+// The address is either a file descriptor number or a name given by FileDescriptorName=
+// of the socket unit, which is looked up in LISTEN_FDNAMES.
+// A number of "fd" network is used as is, while a number of "systemd" network must be one of
+// the file descriptors passed to the current process as told by LISTEN_PID and LISTEN_FDS.
+// A name is looked up in both networks the same way.
+// Each file descriptor can be used only once, since it is closed after it is used.
+//
+// Syntax:
+//
+//	fd:<number> | fd:<name> | systemd:<number> | systemd:<name>
+//
+// Examples:
+//
+//	fd:3
+//	fd:http
+//	systemd:grpc
+type FDLocal string
+
+func (v FDLocal) _localLike() {}
+
+func (v FDLocal) Sanitize() (FDLocal, error) {
+	s := string(v)
+	if s == "" {
+		return "", errors.New("empty local address")
+	}
+
+	net, addr, ok := strings.Cut(s, ":")
+	if !ok || !isFDNetwork(net) {
+		return "", errors.New(`network must be "fd" or "systemd"`)
+	}
+	if addr == "" {
+		return "", errPosF(len(net)+1, "missing file descriptor")
+	}
+	if isDigits(addr) {
+		fd, err := strconv.Atoi(addr)
+		if err != nil {
+			return "", errPos(len(net)+1, err)
+		}
+		return FDLocal(net + ":" + strconv.Itoa(fd)), nil
+	}
+	if err := checkFDName(addr); err != nil {
+		return "", accPosErr(err, len(net)+1)
+	}
+	return FDLocal(s), nil
+}
+
+func isFDNetwork(net string) bool {
+	return net == "fd" || net == "systemd"
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range []byte(s) {
+		if !isDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkFDName checks the name as systemd does for FileDescriptorName=,
+// which is at most 255 printable ASCII characters excluding ':'.
+func checkFDName(name string) error {
+	if len(name) > 255 {
+		return errors.New("file descriptor name too long")
+	}
+	for i, c := range []byte(name) {
+		if c <= ' ' || c >= 0x7f || c == ':' {
+			return errPosF(i, "invalid character in file descriptor name")
+		}
+	}
+	return nil
+}
+
+// listenFDsStart is the first file descriptor passed by the socket activation (SD_LISTEN_FDS_START).
+const listenFDsStart = 3
+
+// listenFDs returns the names of file descriptors passed by the socket activation,
+// in the order of file descriptors starting from [listenFDsStart].
+// It returns nil if LISTEN_PID is not the current process.
+// The name of a file descriptor is empty if LISTEN_FDNAMES is not given.
+func listenFDs() ([]string, error) {
+	pid, ok := os.LookupEnv("LISTEN_PID")
+	if !ok || pid != strconv.Itoa(os.Getpid()) {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS: %q", os.Getenv("LISTEN_FDS"))
+	}
+
+	names := make([]string, n)
+	if v, ok := os.LookupEnv("LISTEN_FDNAMES"); ok {
+		ns := strings.Split(v, ":")
+		if len(ns) != n {
+			return nil, fmt.Errorf("LISTEN_FDNAMES has %d names but LISTEN_FDS is %d", len(ns), n)
+		}
+		copy(names, ns)
+	}
+	return names, nil
+}
+
+// fdOf returns the file descriptor of the address.
+// A name is looked up in LISTEN_FDNAMES, and a number is used as is
+// but it must be passed by the socket activation for "systemd" network.
+func fdOf(network, addr string) (int, error) {
+	if isDigits(addr) && network == "fd" {
+		return strconv.Atoi(addr)
+	}
+
+	names, err := listenFDs()
+	if err != nil {
+		return 0, err
+	}
+	if names == nil {
+		return 0, errors.New("no file descriptors are passed by the socket activation")
+	}
+	if isDigits(addr) {
+		fd, err := strconv.Atoi(addr)
+		if err != nil {
+			return 0, err
+		}
+		if fd < listenFDsStart || fd >= listenFDsStart+len(names) {
+			return 0, fmt.Errorf("file descriptor %d is not passed by the socket activation", fd)
+		}
+		return fd, nil
+	}
+	for i, name := range names {
+		if name == addr {
+			return listenFDsStart + i, nil
+		}
+	}
+	return 0, fmt.Errorf("no file descriptor named %q", addr)
+}
+
+// usedFDs are file descriptors already used, which may be reused by the process for other files.
+var usedFDs = struct {
+	sync.Mutex
+	m map[int]bool
+}{m: map[int]bool{}}
+
+// fileOf returns the file of the inherited file descriptor.
+// The file must be closed by the caller so the file descriptor can be used only once.
+func fileOf(network, addr string) (*os.File, error) {
+	fd, err := fdOf(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	usedFDs.Lock()
+	defer usedFDs.Unlock()
+	if usedFDs.m[fd] {
+		return nil, fmt.Errorf("file descriptor %d is already used", fd)
+	}
+
+	f := os.NewFile(uintptr(fd), network+":"+addr)
+	if f == nil {
+		return nil, fmt.Errorf("invalid file descriptor: %d", fd)
+	}
+	usedFDs.m[fd] = true
+	return f, nil
+}
+
+// listenFD returns a listener of the inherited socket.
+// The inherited file descriptor is closed as the listener owns its duplicate.
+func listenFD(network, addr string) (net.Listener, error) {
+	f, err := fileOf(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%s: %w", network, addr, err)
+	}
+	return l, nil
+}
+
+// listenPacketFD returns a packet connection of the inherited socket.
+// The inherited file descriptor is closed as the connection owns its duplicate.
+func listenPacketFD(network, addr string) (net.PacketConn, error) {
+	f, err := fileOf(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	conn, err := net.FilePacketConn(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%s: %w", network, addr, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,133 @@
+package xddr_test
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestFDLocal(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, tc := range [][]string{
+			{"fd:3", "fd:3"},
+			{"fd:003", "fd:3"},
+			{"fd:http", "fd:http"},
+			{"systemd:3", "systemd:3"},
+			{"systemd:grpc-tls", "systemd:grpc-tls"},
+		} {
+			t.Run(fmt.Sprintf("FDLocal(%q).Sanitize()=%q", tc[0], tc[1]), func(t *testing.T) {
+				v, err := xddr.FDLocal(tc[0]).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, xddr.FDLocal(tc[1]))
+
+				w, err := xddr.TCPUnixLocal(tc[0]).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, w, xddr.TCPUnixLocal(tc[1]))
+			})
+		}
+		for _, tc := range [][]string{
+			{"empty local address", ""},
+			{`network must be "fd" or "systemd"`, "tcp:3"},
+			{"[3]: missing file descriptor", "fd:"},
+			{"[4]: invalid character", "fd:a:b"},
+			{"[9]: invalid character", "systemd:a b"},
+		} {
+			t.Run(fmt.Sprintf("FDLocal(%q).Sanitize() -> %q", tc[1], tc[0]), func(t *testing.T) {
+				_, err := xddr.FDLocal(tc[1]).Sanitize()
+				AssertErrorContains(t, err, tc[0])
+			})
+		}
+	})
+	t.Run("Listen", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("not supported")
+		}
+
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		AssertNoError(t, err)
+		defer l.Close()
+		lf, err := l.(*net.TCPListener).File()
+		AssertNoError(t, err)
+		defer lf.Close()
+
+		c, err := net.ListenPacket("udp", "127.0.0.1:0")
+		AssertNoError(t, err)
+		defer c.Close()
+		cf, err := c.(*net.UDPConn).File()
+		AssertNoError(t, err)
+		defer cf.Close()
+
+		// Sockets are passed as the socket activation does.
+		cmd := exec.Command(os.Args[0], "-test.run=^TestFDLocalActivated$", "-test.v")
+		cmd.ExtraFiles = []*os.File{lf, cf}
+		cmd.Env = append(os.Environ(),
+			"XDDR_TEST_ACTIVATED=1",
+			"XDDR_TEST_TCP="+l.Addr().String(),
+			"XDDR_TEST_UDP="+c.LocalAddr().String(),
+			"LISTEN_FDS=2",
+			"LISTEN_FDNAMES=http:dns",
+		)
+		out, err := cmd.CombinedOutput()
+		Assert(t, err == nil, "activated process failed: %v\n%s", err, out)
+	})
+}
+
+// TestFDLocalActivated runs in a child process spawned by TestFDLocal.
+func TestFDLocalActivated(t *testing.T) {
+	if os.Getenv("XDDR_TEST_ACTIVATED") == "" {
+		t.Skip("run by TestFDLocal")
+	}
+
+	// The service manager sets LISTEN_PID after the fork.
+	t.Setenv("LISTEN_PID", "0")
+	_, err := xddr.Listen(xddr.FDLocal("fd:http"))
+	AssertErrorContains(t, err, "no file descriptors are passed")
+	_, err = xddr.Listen(xddr.FDLocal("systemd:3"))
+	AssertErrorContains(t, err, "no file descriptors are passed")
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+
+	_, err = xddr.Listen(xddr.FDLocal("fd:grpc"))
+	AssertErrorContains(t, err, `no file descriptor named "grpc"`)
+	for _, given := range []xddr.FDLocal{"systemd:2", "systemd:5"} {
+		_, err = xddr.Listen(given)
+		AssertErrorContains(t, err, "is not passed by the socket activation")
+	}
+
+	l, err := xddr.Listen(xddr.HTTPLocal("fd:http"))
+	AssertNoError(t, err)
+	defer l.Close()
+	AssertEq(t, l.Addr().String(), os.Getenv("XDDR_TEST_TCP"))
+
+	go func() {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("hello"))
+	}()
+	conn, err := l.Accept()
+	AssertNoError(t, err)
+	b, err := io.ReadAll(conn)
+	AssertNoError(t, err)
+	AssertEq(t, string(b), "hello")
+	conn.Close()
+
+	// The inherited file descriptor is consumed.
+	for _, given := range []xddr.FDLocal{"fd:http", "fd:3", "systemd:3"} {
+		_, err = xddr.Listen(given)
+		AssertErrorContains(t, err, "file descriptor 3 is already used")
+	}
+
+	p, err := xddr.ListenPacket(xddr.FDLocal("systemd:4"))
+	AssertNoError(t, err)
+	defer p.Close()
+	AssertEq(t, p.LocalAddr().String(), os.Getenv("XDDR_TEST_UDP"))
+}
@@ -86,11 +86,58 @@ func (v GRPC) Local() Local {
 		return Local("tcp4:" + a.HostPort())
 	case "unix":
 		return Local("unix:" + p)
+	case "unix-abstract":
+		return Local("unix:@" + u.Opaque())
 	}
 
 	return Local(s + ":" + u.Opaque())
 }
 
+// Dialer returns the network and the address to dial the target as [net.Dial] takes.
+// Only the first endpoint is given for "ipv4" and "ipv6" schemes with multiple endpoints.
+// The network is empty if the target cannot be dialed without a resolver, such as of "xds" scheme.
+//
+// Examples:
+//
+//	dns:///example.com         -> tcp, example.com:443
+//	ipv6:[::1]:50051           -> tcp6, [::1]:50051
+//	unix:///run/grpc.sock      -> unix, /run/grpc.sock
+//	unix-abstract:grpc         -> unix, @grpc
+//	vsock:3:50051              -> vsock, 3:50051
+func (v GRPC) Dialer() (network, address string) {
+	scheme, rest, _ := strings.Cut(string(v), ":")
+	switch scheme {
+	case "unix":
+		if strings.HasPrefix(rest, "//") {
+			rest = URL(v).Path()
+		}
+		return "unix", rest
+	case "unix-abstract":
+		return "unix", "@" + rest
+	case "vsock", "mem":
+		return scheme, rest
+	}
+
+	hps, err := grpcEndpoints(v)
+	if err != nil {
+		return "", ""
+	}
+	h, port, err := hps[0].Split()
+	if err != nil {
+		return "", ""
+	}
+
+	switch scheme {
+	case "ipv4":
+		network = "tcp4"
+	case "ipv6":
+		network = "tcp6"
+	default:
+		network = "tcp"
+	}
+	return network, string(h.raw()) + ":" + strconv.Itoa(port)
+}
+
 type GRPCLocal string
 
 func (v GRPCLocal) _localLike() {}
@@ -107,10 +154,21 @@ func (v GRPCLocal) WithPort(port int) (GRPCLocal, error) {
 	return transWithErr[GRPCLocal](TCPUnixLocal(v).WithPort(port))
 }
 
+// AsURL returns the target to connect to the local address.
+// An interface of "%<interface>:<port>" is resolved to its address.
+// It returns an empty value if the address cannot be connected by a target, such as an inherited file descriptor.
 func (v GRPCLocal) AsURL() GRPC {
 	net, addr := Local(v).Split()
 	switch net {
 	case "tcp", "tcp4", "tcp6":
+		if name, port, ok := cutInterface(addr); ok {
+			host, ok := interfaceURLHost(net, name)
+			if !ok {
+				return ""
+			}
+			return GRPC("dns:///" + host + ":" + port)
+		}
+
 		_, host, port := Authority(addr).split()
 		switch host {
 		case "":
@@ -124,11 +182,29 @@ func (v GRPCLocal) AsURL() GRPC {
 			host = "127.0.0.1"
 		case "[::]":
 			host = "[::1]"
+		default:
+			host = string(Host(host).escapeZone())
 		}
 		return GRPC("dns:///" + host + ":" + port)
 
 	case "unix":
+		if name, ok := strings.CutPrefix(addr, "@"); ok {
+			return GRPC("unix-abstract:" + name)
+		}
 		return GRPC("unix://" + addr)
+
+	case "vsock":
+		if a, err := parseVsockAddr(addr); err == nil && a.cid == vsockCIDAny {
+			a.cid = vsockCIDLocal
+			addr = a.String()
+		}
+		return GRPC("vsock:" + addr)
+
+	case "mem":
+		return GRPC("mem:" + addr)
+
+	case "fd", "systemd":
+		return ""
 	}
 
 	return GRPC("dns://" + addr)
@@ -27,6 +27,27 @@ func TestGRPC(t *testing.T) {
 			})
 		}
 	})
+	t.Run("Dialer", func(t *testing.T) {
+		for _, tc := range [][]string{
+			{"dns:///grpc.io:50051", "tcp", "grpc.io:50051"},
+			{"dns:///grpc.io", "tcp", "grpc.io:443"},
+			{"dns://8.8.8.8/grpc.io:50051", "tcp", "grpc.io:50051"},
+			{"ipv4:198.51.100.123:50051,198.51.100.124:50051", "tcp4", "198.51.100.123:50051"},
+			{"ipv6:[fe80::1%25eth0]:50051", "tcp6", "[fe80::1%eth0]:50051"},
+			{"unix:///var/run/grpc.sock", "unix", "/var/run/grpc.sock"},
+			{"unix:/var/run/grpc.sock", "unix", "/var/run/grpc.sock"},
+			{"unix-abstract:grpc", "unix", "@grpc"},
+			{"vsock:3:50051", "vsock", "3:50051"},
+			{"mem:grpc", "mem", "grpc"},
+			{"xds:///wallet.grpcwallet.io", "", ""},
+		} {
+			t.Run(fmt.Sprintf("GRPC(%q).Dialer()=(%q, %q)", tc[0], tc[1], tc[2]), func(t *testing.T) {
+				network, address := xddr.GRPC(tc[0]).Dialer()
+				AssertEq(t, network, tc[1])
+				AssertEq(t, address, tc[2])
+			})
+		}
+	})
 }
 
 func TestGRPCLocal(t *testing.T) {
@@ -40,6 +61,9 @@ func TestGRPCLocal(t *testing.T) {
 			{"tcp4:0.0.0.0:80", "tcp4:0.0.0.0:80"},
 			{"tcp6::80", "tcp6:[::]:80"},
 			{"tcp6:[::]:80", "tcp6:[::]:80"},
+			{"/var/run/grpc.sock", "unix:/var/run/grpc.sock"},
+			{"@grpc", "unix:@grpc"},
+			{"unix-abstract:grpc", "unix:@grpc"},
 		} {
 			t.Run(fmt.Sprintf("GRPCLocal(%q).Sanitize()=%q", tc[0], tc[1]), func(t *testing.T) {
 				v, err := xddr.GRPCLocal(tc[0]).Sanitize()
@@ -53,7 +77,11 @@ func TestGRPCLocal(t *testing.T) {
 			{"tcp::80", "dns:///127.0.0.1:80"},
 			{"tcp4:0.0.0.0:80", "dns:///127.0.0.1:80"},
 			{"tcp6:[::]:80", "dns:///[::1]:80"},
+			{"tcp6:[fe80::1%eth0]:80", "dns:///[fe80::1%25eth0]:80"},
 			{"unix:/var/run/grpc.sock", "unix:///var/run/grpc.sock"},
+			{"unix:@grpc", "unix-abstract:grpc"},
+			{"fd:grpc", ""},
+			{"tcp:%xddr-none:8080", ""},
 		} {
 			t.Run(fmt.Sprintf("GRPCLocal(%q).AsURL()=%q", tc[0], tc[1]), func(t *testing.T) {
 				v := xddr.GRPCLocal(tc[0]).AsURL()
@@ -7,15 +7,26 @@ import (
 )
 
 // Host represents a host component of Authority, and is a union of IPv4, IPv6, or Domain.
-// Since Host is a part of Authority, IPv6 address must be enclosed in square brackets.
+// Since Host is a part of Authority, IPv6 address must be enclosed in square brackets
+// and its zone identifier is percent-encoded as described in RFC 6874.
 //
 // Examples:
 //
 //	127.0.0.1
 //	[::1]
+//	[fe80::1%25eth0]
 //	example.com
 type Host string
 
+// hostOfIPv6 encloses the IPv6 address in square brackets and percent-encodes its zone.
+func hostOfIPv6(v IPv6) Host {
+	addr, zone, ok := strings.Cut(string(v), "%")
+	if !ok {
+		return Host("[" + addr + "]")
+	}
+	return Host("[" + addr + "%25" + percent_encode(zone, isUrlUnreserved) + "]")
+}
+
 func (v Host) Sanitize() (Host, error) {
 	s := string(v)
 	if s == "" {
@@ -26,7 +37,7 @@ func (v Host) Sanitize() (Host, error) {
 		if err != nil {
 			return "", err
 		}
-		return Host("[" + w + "]"), nil
+		return hostOfIPv6(w), nil
 	}
 	if w, err := IPv4(s).Sanitize(); err == nil {
 		return Host(w), nil
@@ -63,6 +74,61 @@ func (v Host) Domain() (Domain, bool) {
 	return "", false
 }
 
+// IP returns the host as an IP address without square brackets.
+// Zone identifier of the IPv6 address is decoded.
+func (v Host) IP() (IP, bool) {
+	switch {
+	case v.IsIPv4():
+		return IP(v), true
+	case v.IsIPv6():
+		s := strings.TrimSuffix(string(v[1:]), "]")
+		addr, zone, ok := strings.Cut(s, "%")
+		if !ok {
+			return IP(addr), true
+		}
+		if z, ok := strings.CutPrefix(zone, "25"); ok {
+			if z, err := percent_decode_all(z); err == nil {
+				zone = z
+			}
+		}
+		return IP(addr + "%" + zone), true
+	default:
+		return "", false
+	}
+}
+
+// Zone returns the decoded zone identifier of the IPv6 host, if any.
+func (v Host) Zone() string {
+	ip, ok := v.IP()
+	if !ok {
+		return ""
+	}
+	return ip.Zone()
+}
+
+// raw returns the host with the zone identifier of the IPv6 address not encoded,
+// which is the form used by net package.
+func (v Host) raw() Host {
+	if !v.IsIPv6() {
+		return v
+	}
+	ip, _ := v.IP()
+	return Host("[" + ip + "]")
+}
+
+// escapeZone percent-encodes the zone identifier of the IPv6 host
+// which is given in the raw form.
+func (v Host) escapeZone() Host {
+	if !v.IsIPv6() || !strings.Contains(string(v), "%") {
+		return v
+	}
+	w, err := IPv6(v).Sanitize()
+	if err != nil {
+		return v
+	}
+	return hostOfIPv6(w)
+}
+
 func (v Host) IsIPv4() bool {
 	if v == "" {
 		return false
@@ -95,8 +161,47 @@ func (v Host) IsIPv6() bool {
 	return v != "" && v[0] == '['
 }
 
+// IsDomain reports whether the host is a domain name.
+// Note that a host that looks like an IPv4 address in a legacy notation is not a domain.
 func (v Host) IsDomain() bool {
-	return !v.IsIPv4() && !v.IsIPv6()
+	return !v.IsIPv4() && !v.IsIPv6() && !v.IsLegacyIPv4()
+}
+
+// IsLegacyIPv4 reports whether the host is neither IPv4 in dotted decimal notation nor IPv6
+// but its last label is a number, e.g. "0x7f.1", "0177.0.0.1", or "2130706433".
+// Such hosts are interpreted as IPv4 addresses by inet_aton(3) and web browsers
+// (see "ends in a number checker" of WHATWG URL Standard) so they must not be treated
+// as domain names, which is a common way to bypass SSRF protection.
+func (v Host) IsLegacyIPv4() bool {
+	if v == "" || v.IsIPv4() || v.IsIPv6() {
+		return false
+	}
+
+	s := strings.TrimSuffix(string(v), ".")
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		s = s[i+1:]
+	}
+	if s == "" {
+		return false
+	}
+	if t, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+		return strings.Trim(t, "0123456789abcdef") == ""
+	}
+	return strings.Trim(s, "0123456789") == ""
+}
+
+// LegacyIPv4 converts the host in a legacy IPv4 notation into the canonical one.
+// See [Host.IsLegacyIPv4] and [IPv4.SanitizeLegacy].
+func (v Host) LegacyIPv4() (IPv4, bool) {
+	if !v.IsLegacyIPv4() {
+		return "", false
+	}
+
+	w, err := IPv4(v).SanitizeLegacy()
+	if err != nil {
+		return "", false
+	}
+	return w, true
 }
 
 // HostPort represents a host and port pair, where host is a union of IPv4, IPv6, or Domain.
@@ -0,0 +1,56 @@
+package xddr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestHost(t *testing.T) {
+	t.Run("LegacyIPv4", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.Host
+			want  xddr.IPv4
+		}{
+			{"0x7f.1", "127.0.0.1"},
+			{"0177.0.0.1", "127.0.0.1"},
+			{"2130706433", "127.0.0.1"},
+			{"127.1", "127.0.0.1"},
+			{"0x7f.0x0.0x0.0x1.", "127.0.0.1"},
+		} {
+			t.Run(fmt.Sprintf("Host(%q).LegacyIPv4()=%q", tc.given, tc.want), func(t *testing.T) {
+				Assert(t, tc.given.IsLegacyIPv4(), "want legacy IPv4")
+				Assert(t, !tc.given.IsDomain(), "want not domain")
+
+				v, ok := tc.given.LegacyIPv4()
+				Assert(t, ok, "want legacy IPv4")
+				AssertEq(t, v, tc.want)
+			})
+		}
+		for _, given := range []xddr.Host{
+			"127.0.0.1",
+			"[::1]",
+			"example.com",
+			"1.example",
+			"1.0x7g",
+		} {
+			t.Run(fmt.Sprintf("Host(%q).IsLegacyIPv4()=false", given), func(t *testing.T) {
+				Assert(t, !given.IsLegacyIPv4(), "want not legacy IPv4")
+			})
+		}
+
+		// Ends in a number but cannot be an IPv4 address.
+		h := xddr.Host("1.2.3.4.5")
+		Assert(t, h.IsLegacyIPv4(), "want legacy IPv4")
+		_, ok := h.LegacyIPv4()
+		Assert(t, !ok, "want not convertible")
+	})
+	t.Run("URL", func(t *testing.T) {
+		u, err := xddr.URL("http://0x7f.1:8080/admin").Sanitize()
+		AssertNoError(t, err)
+		Assert(t, u.Host().IsLegacyIPv4(), "want legacy IPv4")
+		_, ok := u.Host().Domain()
+		Assert(t, !ok, "want not domain")
+	})
+}
@@ -0,0 +1,254 @@
+package xddr
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// HostsEntry is an entry of a hosts file which maps an IP address to its names.
+// The first name is the canonical one and the rest are aliases.
+type HostsEntry struct {
+	IP    IP
+	Names []Domain
+
+	// Comment trailing the entry without the leading '#'.
+	Comment string
+}
+
+func (e HostsEntry) String() string {
+	var r strings.Builder
+	r.WriteString(string(e.IP))
+	for i, name := range e.Names {
+		if i == 0 {
+			r.WriteByte('\t')
+		} else {
+			r.WriteByte(' ')
+		}
+		r.WriteString(string(name))
+	}
+	if e.Comment != "" {
+		r.WriteString(" #")
+		r.WriteString(e.Comment)
+	}
+	return r.String()
+}
+
+type hostsLine struct {
+	// Entry of the line, nil if the line is blank or a comment.
+	entry *HostsEntry
+
+	// Original text of the line, empty if the entry is added or modified.
+	raw string
+}
+
+// HostsFile represents a hosts file such as "/etc/hosts" (see hosts(5)).
+// Comments and blank lines are kept so the file can be rewritten without losing them.
+type HostsFile struct {
+	lines []hostsLine
+}
+
+// ParseHostsFile parses the hosts file.
+// Addresses and names are validated by [IP.Sanitize] and [Domain.Sanitize].
+// Position of the error is the line number.
+func ParseHostsFile(r io.Reader) (*HostsFile, error) {
+	f := &HostsFile{}
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+
+		text, comment, _ := strings.Cut(line, "#")
+		fs := strings.Fields(text)
+		if len(fs) == 0 {
+			f.lines = append(f.lines, hostsLine{raw: line})
+			continue
+		}
+		if len(fs) < 2 {
+			return nil, errPosF(n, "missing host name for %q", fs[0])
+		}
+
+		ip, err := IP(fs[0]).Sanitize()
+		if err != nil {
+			return nil, errPosF(n, "invalid IP address %q: %w", fs[0], err)
+		}
+
+		names := make([]Domain, 0, len(fs)-1)
+		for _, name := range fs[1:] {
+			d, err := Domain(name).Sanitize()
+			if err != nil {
+				return nil, errPosF(n, "invalid host name %q: %w", name, err)
+			}
+			names = append(names, d)
+		}
+
+		f.lines = append(f.lines, hostsLine{
+			entry: &HostsEntry{IP: ip, Names: names, Comment: comment},
+			raw:   line,
+		})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// LoadHostsFile reads the hosts file from the file.
+func LoadHostsFile(name string) (*HostsFile, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h, err := ParseHostsFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return h, nil
+}
+
+// WriteTo writes the hosts file.
+// Lines which are not modified are written as they were parsed.
+func (f *HostsFile) WriteTo(w io.Writer) (int64, error) {
+	var r strings.Builder
+	for _, line := range f.lines {
+		if line.raw == "" && line.entry != nil {
+			r.WriteString(line.entry.String())
+		} else {
+			r.WriteString(line.raw)
+		}
+		r.WriteByte('\n')
+	}
+
+	n, err := io.WriteString(w, r.String())
+	return int64(n), err
+}
+
+func (f *HostsFile) String() string {
+	var r strings.Builder
+	f.WriteTo(&r)
+	return r.String()
+}
+
+// Entries returns the entries in the order of the file.
+func (f *HostsFile) Entries() []HostsEntry {
+	es := []HostsEntry{}
+	for _, line := range f.lines {
+		if line.entry != nil {
+			es = append(es, *line.entry)
+		}
+	}
+	return es
+}
+
+// Lookup returns the addresses of the host in the order of the file.
+// Names are matched case-insensitively regardless of the trailing dot.
+func (f *HostsFile) Lookup(host Domain) []IP {
+	ips := []IP{}
+	for _, line := range f.lines {
+		e := line.entry
+		if e == nil || slices.Contains(ips, e.IP) {
+			continue
+		}
+		if slices.ContainsFunc(e.Names, func(name Domain) bool { return equalDomain(name, host) }) {
+			ips = append(ips, e.IP)
+		}
+	}
+	return ips
+}
+
+// lookupHosts returns the addresses of the host in the hosts file as [HostsFile.Lookup] does,
+// but invalid lines are skipped as the system resolver does instead of failing the whole file.
+func lookupHosts(r io.Reader, host Domain) ([]IP, error) {
+	ips := []IP{}
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		text, _, _ := strings.Cut(s.Text(), "#")
+		fs := strings.Fields(text)
+		if len(fs) < 2 {
+			continue
+		}
+
+		ip, err := IP(fs[0]).Sanitize()
+		if err != nil || slices.Contains(ips, ip) {
+			continue
+		}
+		if slices.ContainsFunc(fs[1:], func(name string) bool { return equalDomain(Domain(name), host) }) {
+			ips = append(ips, ip)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return ips, nil
+}
+
+// LookupAddr returns the names of the address in the order of the file.
+func (f *HostsFile) LookupAddr(ip IP) []Domain {
+	w, err := ip.Sanitize()
+	if err != nil {
+		return nil
+	}
+
+	names := []Domain{}
+	for _, line := range f.lines {
+		e := line.entry
+		if e == nil || e.IP != w {
+			continue
+		}
+		for _, name := range e.Names {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// Add maps the names to the address.
+// Names are appended to the first entry of the address if exists,
+// otherwise a new entry is appended to the end of the file.
+func (f *HostsFile) Add(ip IP, names ...Domain) error {
+	if len(names) == 0 {
+		return fmt.Errorf("missing host name")
+	}
+
+	w, err := ip.Sanitize()
+	if err != nil {
+		return fmt.Errorf("invalid IP address %q: %w", ip, err)
+	}
+	ip = w
+
+	ds := make([]Domain, 0, len(names))
+	for _, name := range names {
+		d, err := name.Sanitize()
+		if err != nil {
+			return fmt.Errorf("invalid host name %q: %w", name, err)
+		}
+		ds = append(ds, d)
+	}
+
+	for i := range f.lines {
+		line := &f.lines[i]
+		if line.entry == nil || line.entry.IP != ip {
+			continue
+		}
+
+		for _, d := range ds {
+			if !slices.Contains(line.entry.Names, d) {
+				line.entry.Names = append(line.entry.Names, d)
+				line.raw = ""
+			}
+		}
+		return nil
+	}
+
+	f.lines = append(f.lines, hostsLine{entry: &HostsEntry{IP: ip, Names: ds}})
+	return nil
+}
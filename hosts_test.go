@@ -0,0 +1,147 @@
+package xddr_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+const testHostsFile = `# Static table lookup for hostnames.
+127.0.0.1	localhost
+::1	localhost ip6-localhost ip6-loopback
+
+# Test servers
+192.0.2.1   Example.com www.example.com   # primary
+192.0.2.2   api.example.com
+2001:db8::1 example.com
+`
+
+func TestHostsFile(t *testing.T) {
+	f, err := xddr.ParseHostsFile(strings.NewReader(testHostsFile))
+	AssertNoError(t, err)
+
+	t.Run("Entries", func(t *testing.T) {
+		es := f.Entries()
+		AssertEq(t, len(es), 5)
+		AssertEq(t, es[1].IP, "::1")
+		Assert(t, slices.Equal(es[1].Names, []xddr.Domain{"localhost", "ip6-localhost", "ip6-loopback"}), "got %v", es[1].Names)
+		AssertEq(t, es[2].IP, "192.0.2.1")
+		Assert(t, slices.Equal(es[2].Names, []xddr.Domain{"example.com", "www.example.com"}), "got %v", es[2].Names)
+		AssertEq(t, es[2].Comment, " primary")
+	})
+	t.Run("Lookup", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.Domain
+			want  []xddr.IP
+		}{
+			{"localhost", []xddr.IP{"127.0.0.1", "::1"}},
+			{"example.com", []xddr.IP{"192.0.2.1", "2001:db8::1"}},
+			{"WWW.example.com.", []xddr.IP{"192.0.2.1"}},
+			{"unknown.example.com", []xddr.IP{}},
+		} {
+			t.Run(fmt.Sprintf("Lookup(%q)=%v", tc.given, tc.want), func(t *testing.T) {
+				ips := f.Lookup(tc.given)
+				Assert(t, slices.Equal(ips, tc.want), "want %v, got %v", tc.want, ips)
+			})
+		}
+	})
+	t.Run("LookupAddr", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.IP
+			want  []xddr.Domain
+		}{
+			{"127.0.0.1", []xddr.Domain{"localhost"}},
+			{"0:0:0:0:0:0:0:1", []xddr.Domain{"localhost", "ip6-localhost", "ip6-loopback"}},
+			{"192.0.2.1", []xddr.Domain{"example.com", "www.example.com"}},
+			{"192.0.2.3", []xddr.Domain{}},
+		} {
+			t.Run(fmt.Sprintf("LookupAddr(%q)=%v", tc.given, tc.want), func(t *testing.T) {
+				names := f.LookupAddr(tc.given)
+				Assert(t, slices.Equal(names, tc.want), "want %v, got %v", tc.want, names)
+			})
+		}
+	})
+	t.Run("WriteTo", func(t *testing.T) {
+		AssertEq(t, f.String(), testHostsFile)
+	})
+}
+
+func TestHostsFileAdd(t *testing.T) {
+	f, err := xddr.ParseHostsFile(strings.NewReader(testHostsFile))
+	AssertNoError(t, err)
+
+	err = f.Add("192.0.2.2", "API.example.com", "v2.api.example.com")
+	AssertNoError(t, err)
+	err = f.Add("2001:0db8::2", "db.example.com")
+	AssertNoError(t, err)
+
+	Assert(t, slices.Equal(f.Lookup("v2.api.example.com"), []xddr.IP{"192.0.2.2"}), "want added alias")
+	Assert(t, slices.Equal(f.LookupAddr("2001:db8::2"), []xddr.Domain{"db.example.com"}), "want added entry")
+
+	want := strings.Replace(testHostsFile,
+		"192.0.2.2   api.example.com\n",
+		"192.0.2.2\tapi.example.com v2.api.example.com\n", 1) +
+		"2001:db8::2\tdb.example.com\n"
+	AssertEq(t, f.String(), want)
+
+	err = f.Add("192.0.2.300", "foo.example.com")
+	AssertErrorContains(t, err, "invalid IP address")
+	err = f.Add("192.0.2.3", "foo_bar.example.com")
+	AssertErrorContains(t, err, "invalid host name")
+	err = f.Add("192.0.2.3")
+	AssertErrorContains(t, err, "missing host name")
+}
+
+func TestParseHostsFile(t *testing.T) {
+	for _, tc := range [][]string{
+		{"[1]: missing host name",
+			"127.0.0.1",
+			"127.0.0.1 # localhost",
+		},
+		{"[2]: invalid IP address",
+			"# comment\nlocalhost 127.0.0.1",
+			"\n127.0.0.256 localhost",
+		},
+		{"[1]: invalid host name",
+			"127.0.0.1 local_host",
+			"127.0.0.1 localhost -foo",
+		},
+	} {
+		for _, given := range tc[1:] {
+			t.Run(fmt.Sprintf("ParseHostsFile(%q) -> %q", given, tc[0]), func(t *testing.T) {
+				_, err := xddr.ParseHostsFile(strings.NewReader(given))
+				AssertErrorContains(t, err, tc[0])
+			})
+		}
+	}
+}
+
+func TestLoadHostsFile(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "hosts")
+	err := os.WriteFile(p, []byte(testHostsFile), 0o644)
+	AssertNoError(t, err)
+
+	f, err := xddr.LoadHostsFile(p)
+	AssertNoError(t, err)
+	err = f.Add("192.0.2.3", "new.example.com")
+	AssertNoError(t, err)
+
+	w, err := os.Create(p)
+	AssertNoError(t, err)
+	_, err = f.WriteTo(w)
+	AssertNoError(t, err)
+	AssertNoError(t, w.Close())
+
+	f, err = xddr.LoadHostsFile(p)
+	AssertNoError(t, err)
+	Assert(t, slices.Equal(f.Lookup("new.example.com"), []xddr.IP{"192.0.2.3"}), "want written entry")
+	Assert(t, strings.HasPrefix(f.String(), "# Static table lookup for hostnames.\n"), "want comment preserved")
+
+	_, err = xddr.LoadHostsFile(filepath.Join(t.TempDir(), "missing"))
+	Assert(t, os.IsNotExist(err), "want not exist error, got %v", err)
+}
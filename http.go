@@ -1,12 +1,19 @@
 package xddr
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+)
 
 type HTTP string
 
 func (v HTTP) _urlLike() {}
 
 func (v HTTP) Sanitize() (HTTP, error) {
+	if _, _, ok := cutHTTPUnix(string(v)); ok {
+		return sanitizeHTTPUnix(string(v))
+	}
+
 	u, err := URL(v).Sanitize()
 	if err != nil {
 		return "", err
@@ -14,7 +21,7 @@ func (v HTTP) Sanitize() (HTTP, error) {
 
 	s, _, a, p, f, q := u.split()
 	if s != "http" && s != "https" {
-		return "", errors.New("scheme is not http or https")
+		return "", errors.New("scheme is not http, https or http+unix")
 	}
 
 	port := a.Port()
@@ -68,6 +75,18 @@ func (v HTTP) WithPort(port int) (HTTP, error) {
 	return HTTP(u), nil
 }
 
+// Dialer returns the network and the address to dial the URL as [net.Dial] takes,
+// where the port is the default port of the scheme if omitted.
+// The URL of "http+unix" scheme is dialed to its unix socket.
+func (v HTTP) Dialer() (network, address string) {
+	if path, ok := v.SocketPath(); ok {
+		return "unix", path
+	}
+
+	h := URL(v).Host()
+	return "tcp", string(h.raw()) + ":" + strconv.Itoa(v.Port())
+}
+
 type HTTPLocal string
 
 func (v HTTPLocal) _localLike() {}
@@ -84,10 +103,22 @@ func (v HTTPLocal) WithPort(port int) (HTTPLocal, error) {
 	return transWithErr[HTTPLocal](TCPUnixLocal(v).WithPort(port))
 }
 
+// AsURL returns the URL to connect to the local address.
+// An interface of "%<interface>:<port>" is resolved to its address.
+// It returns an empty value if the address cannot be connected by a URL,
+// such as an inherited file descriptor, a vsock address, or an in-process address.
 func (v HTTPLocal) AsURL() HTTP {
 	net, addr := Local(v).Split()
 	switch net {
 	case "tcp", "tcp4", "tcp6":
+		if name, port, ok := cutInterface(addr); ok {
+			host, ok := interfaceURLHost(net, name)
+			if !ok {
+				return ""
+			}
+			return HTTP("http://" + host + ":" + port)
+		}
+
 		_, host, port := Authority(addr).split()
 		switch host {
 		case "":
@@ -101,11 +132,16 @@ func (v HTTPLocal) AsURL() HTTP {
 			host = "127.0.0.1"
 		case "[::]":
 			host = "[::1]"
+		default:
+			host = string(Host(host).escapeZone())
 		}
 		return HTTP("http://" + host + ":" + port)
 
 	case "unix":
-		return HTTP("unix://" + addr)
+		return HTTP(schemeHTTPUnix + "://" + percent_encode(addr, isUrlUnreserved))
+
+	case "fd", "systemd", "vsock", "mem":
+		return ""
 	}
 
 	return HTTP("http://" + addr)
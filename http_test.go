@@ -21,6 +21,10 @@ func TestHTTP(t *testing.T) {
 			{"https://foo", "https://foo"},
 			{"https://foo:80", "https://foo:80"},
 			{"https://foo:443", "https://foo"},
+			{"http+unix://%2Fvar%2Frun%2Fdocker.sock", "http+unix://%2Fvar%2Frun%2Fdocker.sock"},
+			{"HTTP+UNIX://%2fvar%2frun%2fdocker.sock/v1.41/info?all=1", "http+unix://%2Fvar%2Frun%2Fdocker.sock/v1.41/info?all=1"},
+			{"http+unix://%40app/healthz", "http+unix://%40app/healthz"},
+			{"http+unix://app.sock", "http+unix://app.sock"},
 		} {
 			t.Run(string(tc.given), func(t *testing.T) {
 				value, err := tc.given.Sanitize()
@@ -28,6 +32,38 @@ func TestHTTP(t *testing.T) {
 				AssertEq(t, value, tc.normalized)
 			})
 		}
+		for _, tc := range [][]string{
+			{"scheme is not http", "ftp://foo", "unix:///var/run/docker.sock"},
+			{"[12]: missing socket path", "http+unix://", "http+unix:///foo"},
+			{"[18]: socket path must be percent-encoded", "http+unix://%2Fvar:80"},
+			{"[12]: missing abstract socket name", "http+unix://%40"},
+			{"[12]: incomplete percent-encoding", "http+unix://%2"},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("HTTP(%q).Sanitize() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.HTTP(given).Sanitize()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+	t.Run("Dialer", func(t *testing.T) {
+		for _, tc := range [][]string{
+			{"http://foo", "tcp", "foo:80"},
+			{"https://foo/bar", "tcp", "foo:443"},
+			{"https://foo:8443", "tcp", "foo:8443"},
+			{"http://192.0.2.1", "tcp", "192.0.2.1:80"},
+			{"http://[::1]:8080", "tcp", "[::1]:8080"},
+			{"http://[fe80::1%25eth0]", "tcp", "[fe80::1%eth0]:80"},
+			{"http+unix://%2Fvar%2Frun%2Fdocker.sock/v1.41/info", "unix", "/var/run/docker.sock"},
+			{"http+unix://%40app", "unix", "@app"},
+		} {
+			t.Run(fmt.Sprintf("HTTP(%q).Dialer()=(%q, %q)", tc[0], tc[1], tc[2]), func(t *testing.T) {
+				network, address := xddr.HTTP(tc[0]).Dialer()
+				AssertEq(t, network, tc[1])
+				AssertEq(t, address, tc[2])
+			})
+		}
 	})
 }
 
@@ -55,7 +91,14 @@ func TestHTTPLocal(t *testing.T) {
 			{"tcp::80", "http://127.0.0.1:80"},
 			{"tcp4:0.0.0.0:80", "http://127.0.0.1:80"},
 			{"tcp6:[::]:80", "http://[::1]:80"},
-			{"unix:/var/run/.sock", "unix:///var/run/.sock"},
+			{"tcp6:[fe80::1%eth0]:80", "http://[fe80::1%25eth0]:80"},
+			{"unix:/var/run/.sock", "http+unix://%2Fvar%2Frun%2F.sock"},
+			{"unix:@http", "http+unix://%40http"},
+			{"fd:http", ""},
+			{"systemd:3", ""},
+			{"vsock:3:1024", ""},
+			{"mem:foo", ""},
+			{"tcp:%xddr-none:8080", ""},
 		} {
 			t.Run(fmt.Sprintf("HTTPLocal(%q).AsURL()=%q", tc[0], tc[1]), func(t *testing.T) {
 				v := xddr.HTTPLocal(tc[0]).AsURL()
@@ -0,0 +1,218 @@
+package xddr
+
+import (
+	"context"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// schemeHTTPUnix is the scheme of HTTP over a unix socket,
+// whose host is the percent-encoded path of the socket.
+//
+// Examples:
+//
+//	http+unix://%2Fvar%2Frun%2Fdocker.sock/v1.41/info
+//	http+unix://%40app/healthz
+const schemeHTTPUnix = "http+unix"
+
+// cutHTTPUnix cuts the URL of "http+unix" scheme into the path of the socket
+// and the rest of the URL after the host.
+func cutHTTPUnix(s string) (path string, rest string, ok bool) {
+	scheme, r, ok := strings.Cut(s, "://")
+	if !ok || !strings.EqualFold(scheme, schemeHTTPUnix) {
+		return "", "", false
+	}
+
+	host := r
+	if i := strings.IndexAny(r, "/?#"); i >= 0 {
+		host, rest = r[:i], r[i:]
+	}
+	return host, rest, true
+}
+
+func sanitizeHTTPUnix(s string) (HTTP, error) {
+	host, rest, _ := cutHTTPUnix(s)
+	pos := len(schemeHTTPUnix) + len("://")
+	if host == "" {
+		return "", errPosF(pos, "missing socket path")
+	}
+	if i := strings.IndexAny(host, "@:"); i >= 0 {
+		return "", errPosF(pos+i, "socket path must be percent-encoded")
+	}
+
+	path, err := percent_decode_all(host)
+	if err != nil {
+		return "", accPosErr(err, pos)
+	}
+	if err := checkUnixAddress(path); err != nil {
+		return "", errPos(pos, err)
+	}
+
+	// Sanitize the rest as of a URL with a placeholder host.
+	const base = "http://localhost"
+	u, err := URL(base + rest).Sanitize()
+	if err != nil {
+		return "", accPosErr(err, pos+len(host)-len(base))
+	}
+	rest = strings.TrimPrefix(string(u), base)
+
+	return HTTP(schemeHTTPUnix + "://" + percent_encode(path, isUrlUnreserved) + rest), nil
+}
+
+// SocketPath returns the path of the unix socket if the URL is of "http+unix" scheme.
+func (v HTTP) SocketPath() (string, bool) {
+	host, _, ok := cutHTTPUnix(string(v))
+	if !ok {
+		return "", false
+	}
+	path, err := percent_decode_all(host)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// NewRequest returns a new [http.Request] for the URL as [http.NewRequestWithContext] does.
+// The URL of "http+unix" scheme cannot be parsed by [net/url] since its host is percent-encoded,
+// so the request is built with the path of the socket in [url.URL.Host] to be sent by [Transport].
+func (v HTTP) NewRequest(ctx context.Context, method string, body io.Reader) (*http.Request, error) {
+	path, ok := v.SocketPath()
+	if !ok {
+		return http.NewRequestWithContext(ctx, method, string(v), body)
+	}
+
+	_, rest, _ := cutHTTPUnix(string(v))
+	req, err := http.NewRequestWithContext(ctx, method, "http://localhost"+rest, body)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.Scheme = schemeHTTPUnix
+	req.URL.Host = path
+	return req, nil
+}
+
+// Transport is an [http.RoundTripper] which sends requests of "http+unix" URLs over the unix socket
+// given by the host, and the other requests as the underlying transport does.
+// Requests of "http+unix" URLs are made by [HTTP.NewRequest].
+// The Host header of requests over a unix socket is "localhost" unless it is set explicitly.
+//
+// The zero value is ready to use.
+type Transport struct {
+	// Transport to send requests.
+	// A clone of [http.DefaultTransport] is used if nil.
+	// It is cloned to replace its DialContext, so changes after the first request are not applied.
+	Transport *http.Transport
+
+	// Dialer to connect to unix sockets.
+	// The zero [Dialer] is used if nil.
+	Dialer *Dialer
+
+	once sync.Once
+	t    *http.Transport
+}
+
+// unixHostSuffix is the suffix of the placeholder host for a unix socket,
+// which is on the reserved top-level domain so it never collides with real hosts.
+const unixHostSuffix = ".unix.invalid"
+
+var unixHostEncoding = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+// unixHostOf returns the placeholder host for the socket path.
+// The host is unique for the path, so connections are pooled for each socket.
+// It is only a key of the pool; the socket to dial is given by the context of the request.
+func unixHostOf(path string) string {
+	e := strings.ToLower(unixHostEncoding.EncodeToString([]byte(path)))
+
+	// Split into labels not longer than 63.
+	labels := []string{}
+	for len(e) > 63 {
+		labels = append(labels, e[:63])
+		e = e[63:]
+	}
+	labels = append(labels, e)
+	return strings.Join(labels, ".") + unixHostSuffix
+}
+
+func isUnixHost(host string) bool {
+	return strings.HasSuffix(strings.ToLower(strings.TrimSuffix(host, ".")), unixHostSuffix)
+}
+
+type socketPathKey struct{}
+
+// socketPathFrom returns the socket path set by [Transport.RoundTrip] for a request of "http+unix" URL.
+func socketPathFrom(ctx context.Context) (string, bool) {
+	path, ok := ctx.Value(socketPathKey{}).(string)
+	return path, ok
+}
+
+func (t *Transport) transport() *http.Transport {
+	t.once.Do(func() {
+		if t.Transport != nil {
+			t.t = t.Transport.Clone()
+		} else {
+			t.t = http.DefaultTransport.(*http.Transport).Clone()
+		}
+
+		d := t.Dialer
+		if d == nil {
+			d = &Dialer{}
+		}
+		dial := t.t.DialContext
+		if dial == nil {
+			dial = (&net.Dialer{}).DialContext
+		}
+		t.t.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			if path, ok := socketPathFrom(ctx); ok {
+				return d.DialContext(ctx, "unix", path)
+			}
+			return dial(ctx, network, address)
+		}
+
+		// Requests over unix sockets are never proxied.
+		if proxy := t.t.Proxy; proxy != nil {
+			t.t.Proxy = func(req *http.Request) (*url.URL, error) {
+				if _, ok := socketPathFrom(req.Context()); ok {
+					return nil, nil
+				}
+				return proxy(req)
+			}
+		}
+	})
+	return t.t
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL == nil || !strings.EqualFold(req.URL.Scheme, schemeHTTPUnix) {
+		// The placeholder host must not reach a connection to a unix socket in the pool,
+		// e.g. by a URL from a response or a redirect.
+		if req.URL != nil && isUnixHost(req.URL.Hostname()) {
+			return nil, fmt.Errorf("host %q is reserved for unix sockets", req.URL.Hostname())
+		}
+		return t.transport().RoundTrip(req)
+	}
+
+	path := req.URL.Host
+	if path == "" {
+		return nil, errors.New("missing socket path")
+	}
+
+	r := req.Clone(context.WithValue(req.Context(), socketPathKey{}, path))
+	r.URL.Scheme = "http"
+	r.URL.Host = unixHostOf(path)
+	if r.Host == "" || r.Host == req.URL.Host {
+		r.Host = "localhost"
+	}
+	return t.transport().RoundTrip(r)
+}
+
+// CloseIdleConnections closes idle connections of the underlying transport.
+func (t *Transport) CloseIdleConnections() {
+	t.transport().CloseIdleConnections()
+}
@@ -0,0 +1,139 @@
+package xddr_test
+
+import (
+	"context"
+	"encoding/base32"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestTransport(t *testing.T) {
+	serve := func(t *testing.T, l xddr.HTTPLocal) xddr.HTTP {
+		b, err := xddr.Listen(l)
+		AssertNoError(t, err)
+
+		s := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, r.Host+" "+r.URL.RequestURI())
+		})}
+		go s.Serve(b)
+		t.Cleanup(func() { s.Close() })
+
+		return b.HTTP()
+	}
+	get := func(t *testing.T, c *http.Client, u string) string {
+		req, err := xddr.HTTP(u).NewRequest(context.Background(), http.MethodGet, nil)
+		AssertNoError(t, err)
+
+		res, err := c.Do(req)
+		AssertNoError(t, err)
+		defer res.Body.Close()
+
+		v, err := io.ReadAll(res.Body)
+		AssertNoError(t, err)
+		return string(v)
+	}
+
+	// Requests over unix sockets are not proxied.
+	t.Setenv("HTTP_PROXY", "http://127.0.0.1:1")
+
+	c := &http.Client{Transport: &xddr.Transport{}}
+	t.Run("Unix", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("unix socket")
+		}
+
+		u := serve(t, xddr.HTTPLocal("unix:"+filepath.Join(t.TempDir(), "test.sock")))
+		Assert(t, strings.HasPrefix(string(u), "http+unix://%2F"), "want http+unix URL, got %s", u)
+
+		AssertEq(t, get(t, c, string(u)+"/foo?bar=baz"), "localhost /foo?bar=baz")
+		AssertEq(t, get(t, c, string(u)), "localhost /")
+	})
+	t.Run("Abstract", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("abstract unix socket")
+		}
+
+		u := serve(t, xddr.HTTPLocal("unix:@xddr-transport-test"))
+		AssertEq(t, u, "http+unix://%40xddr-transport-test")
+		AssertEq(t, get(t, c, string(u)+"/foo"), "localhost /foo")
+	})
+	t.Run("LongPath", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("unix socket")
+		}
+
+		// Placeholder host of the path is longer than a DNS label.
+		p := filepath.Join(t.TempDir(), strings.Repeat("a", 50)+".sock")
+		if len(p) > 107 {
+			t.Skip("temp dir is too long")
+		}
+		u := serve(t, xddr.HTTPLocal("unix:"+p))
+		AssertEq(t, get(t, c, string(u)+"/foo"), "localhost /foo")
+	})
+	t.Run("TCP", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, r.URL.RequestURI())
+		}))
+		defer s.Close()
+
+		AssertEq(t, get(t, c, s.URL+"/foo"), "/foo")
+	})
+	t.Run("PlaceholderHost", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("unix socket")
+		}
+
+		p := filepath.Join(t.TempDir(), "test.sock")
+		b, err := xddr.Listen(xddr.HTTPLocal("unix:" + p))
+		AssertNoError(t, err)
+
+		var n atomic.Int32
+		s := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n.Add(1)
+		})}
+		go s.Serve(b)
+		defer s.Close()
+
+		// A connection to the socket is pooled.
+		get(t, c, string(b.HTTP()))
+		AssertEq(t, n.Load(), int32(1))
+
+		// Host of the socket as the transport names it internally.
+		e := strings.ToLower(base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(p)))
+		labels := []string{}
+		for len(e) > 63 {
+			labels = append(labels, e[:63])
+			e = e[63:]
+		}
+		host := strings.Join(append(labels, e), ".") + ".unix.invalid"
+
+		_, err = c.Get("http://" + host + "/")
+		AssertErrorContains(t, err, "reserved for unix sockets")
+
+		redirect := httptest.NewServer(http.RedirectHandler("http://"+host+"/", http.StatusFound))
+		defer redirect.Close()
+		_, err = c.Get(redirect.URL)
+		AssertErrorContains(t, err, "reserved for unix sockets")
+
+		AssertEq(t, n.Load(), int32(1))
+	})
+	t.Run("Refused", func(t *testing.T) {
+		u := xddr.HTTPLocal("unix:" + filepath.Join(t.TempDir(), "none.sock")).AsURL()
+		req, err := u.NewRequest(context.Background(), http.MethodGet, nil)
+		AssertNoError(t, err)
+		AssertEq(t, req.URL.String(), string(u))
+
+		_, err = c.Do(req)
+		Assert(t, errors.Is(err, syscall.ENOENT), "want ENOENT, got %v", err)
+	})
+}
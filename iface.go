@@ -0,0 +1,215 @@
+package xddr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// cutInterface cuts the address in the form of "%<interface>:<port>".
+func cutInterface(address string) (name, port string, ok bool) {
+	if !strings.HasPrefix(address, "%") {
+		return "", "", false
+	}
+	i := strings.LastIndexByte(address, ':')
+	if i < 0 {
+		return "", "", false
+	}
+	return address[1:i], address[i+1:], true
+}
+
+// cutInterfaceHost cuts the address of a local address whose host is an interface name.
+// The name is given with the "%" prefix, or as it is if it looks like neither an IPv4 address nor a domain name.
+// A name with dots is a domain name unless its last label is numeric such as "eth0.100" of VLAN.
+func cutInterfaceHost(address string) (name, port string, ok bool) {
+	if name, port, ok := cutInterface(address); ok {
+		return name, port, true
+	}
+
+	i := strings.LastIndexByte(address, ':')
+	if i <= 0 || address[0] == '[' {
+		return "", "", false
+	}
+	name = address[:i]
+	if strings.Trim(name, "0123456789.") == "" {
+		// IPv4 address.
+		return "", "", false
+	}
+	if name == "localhost" {
+		return "", "", false
+	}
+	if j := strings.LastIndexByte(name, '.'); j >= 0 && !isDigits(name[j+1:]) {
+		return "", "", false
+	}
+	return name, address[i+1:], true
+}
+
+// checkInterfaceName checks the name as Linux does, which is at most 15 bytes
+// without whitespaces, '/', and ':'.
+func checkInterfaceName(name string) error {
+	if name == "" {
+		return errors.New("missing interface name")
+	}
+	if len(name) > 15 {
+		return errors.New("interface name too long")
+	}
+	if name == "." || name == ".." {
+		return errors.New("invalid interface name")
+	}
+	for i, c := range []byte(name) {
+		if c <= ' ' || c >= 0x7f || c == '/' || c == ':' || c == '%' {
+			return errPosF(i, "invalid character in interface name")
+		}
+	}
+	return nil
+}
+
+// interfaceIP returns an address of the interface for the network.
+// IPv4 address is preferred if the network does not specify the family.
+// A link-local IPv6 address, with the zone of the interface, is returned
+// only if the interface has no other IPv6 address.
+func interfaceIP(network, name string) (IP, error) {
+	ifi, err := net.InterfaceByName(name)
+	if err != nil {
+		return "", err
+	}
+	addrs, err := ifi.Addrs()
+	if err != nil {
+		return "", err
+	}
+
+	v4 := network[len(network)-1] != '6'
+	v6 := network[len(network)-1] != '4'
+
+	var ip6, ll6 IP
+	for _, addr := range addrs {
+		n, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if ip := n.IP.To4(); ip != nil {
+			if v4 {
+				return IP(ip.String()), nil
+			}
+			continue
+		}
+		if !v6 {
+			continue
+		}
+		if n.IP.IsLinkLocalUnicast() {
+			if ll6 == "" {
+				ll6 = IP(n.IP.String() + "%" + name)
+			}
+		} else if ip6 == "" {
+			ip6 = IP(n.IP.String())
+		}
+	}
+	if ip6 != "" {
+		return ip6, nil
+	}
+	if ll6 != "" {
+		return ll6, nil
+	}
+	return "", fmt.Errorf("interface %s has no address for %s", name, network)
+}
+
+// interfaceURLHost returns the host of a URL to connect to the interface,
+// where the zone of an IPv6 address is percent-encoded.
+func interfaceURLHost(network, name string) (string, bool) {
+	ip, err := interfaceIP(network, name)
+	if err != nil {
+		return "", false
+	}
+	if ipv6, ok := ip.V6(); ok {
+		return string(Host("[" + string(ipv6) + "]").escapeZone()), true
+	}
+	return string(ip), true
+}
+
+// interfaceAddress returns the address of "<ip>:<port>" for the interface.
+func interfaceAddress(network, name, port string) (string, error) {
+	ip, err := interfaceIP(network, name)
+	if err != nil {
+		return "", err
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return "", fmt.Errorf("invalid port: %q", port)
+	}
+	return string(ipPortOf(ip, p)), nil
+}
+
+// wildcardOf returns the unspecified address of the network with the port.
+func wildcardOf(network, port string) string {
+	switch network[len(network)-1] {
+	case '4':
+		return "0.0.0.0:" + port
+	case '6':
+		return "[::]:" + port
+	default:
+		return ":" + port
+	}
+}
+
+// listenConfigOn returns the config which binds the socket to the interface.
+func (c *ListenConfig) listenConfigOn(name string) net.ListenConfig {
+	lc := c.listenConfig()
+	control := lc.Control
+	lc.Control = func(network, address string, conn syscall.RawConn) error {
+		if control != nil {
+			if err := control(network, address, conn); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if cerr := conn.Control(func(fd uintptr) {
+			err = bindToDevice(fd, name)
+		}); cerr != nil {
+			return cerr
+		}
+		return err
+	}
+	return lc
+}
+
+// listenInterface listens on the interface by SO_BINDTODEVICE where it is supported and permitted,
+// or on the address of the interface.
+func (c *ListenConfig) listenInterface(ctx context.Context, network, name, port string) (net.Listener, error) {
+	if canBindToDevice {
+		lc := c.listenConfigOn(name)
+		l, err := lc.Listen(ctx, network, wildcardOf(network, port))
+		if !errors.Is(err, syscall.EPERM) {
+			return l, err
+		}
+	}
+
+	address, err := interfaceAddress(network, name, port)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+	lc := c.listenConfig()
+	return lc.Listen(ctx, network, address)
+}
+
+// listenPacketInterface is [ListenConfig.listenInterface] for packet connections.
+func (c *ListenConfig) listenPacketInterface(ctx context.Context, network, name, port string) (net.PacketConn, error) {
+	if canBindToDevice {
+		lc := c.listenConfigOn(name)
+		conn, err := lc.ListenPacket(ctx, network, wildcardOf(network, port))
+		if !errors.Is(err, syscall.EPERM) {
+			return conn, err
+		}
+	}
+
+	address, err := interfaceAddress(network, name, port)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+	lc := c.listenConfig()
+	return lc.ListenPacket(ctx, network, address)
+}
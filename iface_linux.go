@@ -0,0 +1,9 @@
+package xddr
+
+import "syscall"
+
+const canBindToDevice = true
+
+func bindToDevice(fd uintptr, name string) error {
+	return syscall.BindToDevice(int(fd), name)
+}
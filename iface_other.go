@@ -0,0 +1,11 @@
+//go:build !linux
+
+package xddr
+
+import "errors"
+
+const canBindToDevice = false
+
+func bindToDevice(fd uintptr, name string) error {
+	return errors.New("SO_BINDTODEVICE is not supported on this platform")
+}
@@ -0,0 +1,116 @@
+package xddr_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestInterfaceLocal(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, tc := range [][]string{
+			{"tcp:%eth0:8080", "tcp:%eth0:8080"},
+			{"tcp:eth0:8080", "tcp:%eth0:8080"},
+			{"tcp4:%lo:8080", "tcp4:%lo:8080"},
+			{"eth0:8080", "tcp:%eth0:8080"},
+			{"%eth0:8080", "tcp:%eth0:8080"},
+			{"tcp6:eth0.100:80", "tcp6:%eth0.100:80"},
+			{"tcp6:%eth0.100:80", "tcp6:%eth0.100:80"},
+			{"tcp:%wg-vpn:080", "tcp:%wg-vpn:80"},
+		} {
+			t.Run(fmt.Sprintf("TCPLocal(%q).Sanitize()=%q", tc[0], tc[1]), func(t *testing.T) {
+				v, err := xddr.TCPLocal(tc[0]).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, xddr.TCPLocal(tc[1]))
+
+				w, err := xddr.TCPUnixLocal(tc[0]).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, w, xddr.TCPUnixLocal(tc[1]))
+			})
+		}
+
+		v, err := xddr.UDPLocal("udp:eth0:53").Sanitize()
+		AssertNoError(t, err)
+		AssertEq(t, v, "udp:%eth0:53")
+
+		for _, tc := range [][]string{
+			{"missing interface name", "tcp:%:80"},
+			{"interface name too long", "tcp:%averyverylongname:80"},
+			{"invalid character", "tcp:%eth 0:80", "tcp:%eth/0:80"},
+			{"invalid port", "tcp:%eth0:http", "tcp:eth0:65536"},
+			{"host is not an IP address", "tcp:example.com:80", "tcp:localhost:80"},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("TCPLocal(%q).Sanitize() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.TCPLocal(given).Sanitize()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+
+	ifis, err := net.Interfaces()
+	AssertNoError(t, err)
+
+	interfaceOf := func(t *testing.T, loopback bool) string {
+		for _, ifi := range ifis {
+			if ifi.Flags&net.FlagUp == 0 || (ifi.Flags&net.FlagLoopback != 0) != loopback {
+				continue
+			}
+			addrs, _ := ifi.Addrs()
+			for _, addr := range addrs {
+				if n, ok := addr.(*net.IPNet); ok && n.IP.To4() != nil {
+					return ifi.Name
+				}
+			}
+		}
+		t.Skip("no interface with IPv4 address")
+		return ""
+	}
+
+	ctx := context.Background()
+	t.Run("Loopback", func(t *testing.T) {
+		lo := interfaceOf(t, true)
+
+		l, err := xddr.Listen(xddr.TCPLocal("tcp4:%" + lo + ":0"))
+		AssertNoError(t, err)
+		defer l.Close()
+
+		_, port, _ := strings.Cut(l.Addr().String(), ":")
+		conn, err := xddr.Dial(ctx, xddr.TCPLocal("tcp4:%"+lo+":"+port))
+		AssertNoError(t, err)
+		defer conn.Close()
+		Assert(t, strings.HasPrefix(conn.RemoteAddr().String(), "127."), "want loopback address, got %s", conn.RemoteAddr())
+
+		// URLs are of the address of the interface.
+		AssertEq(t, xddr.HTTPLocal("tcp4:%"+lo+":"+port).AsURL(), xddr.HTTP("http://127.0.0.1:"+port))
+		AssertEq(t, xddr.GRPCLocal("tcp4:%"+lo+":"+port).AsURL(), xddr.GRPC("dns:///127.0.0.1:"+port))
+
+		p, err := xddr.ListenPacket(xddr.UDPLocal("udp4:%" + lo + ":0"))
+		AssertNoError(t, err)
+		p.Close()
+
+		_, err = xddr.Listen(xddr.TCPLocal("tcp:%xddr-none:0"))
+		AssertErrorContains(t, err, "no such")
+	})
+	t.Run("Other", func(t *testing.T) {
+		name := interfaceOf(t, false)
+
+		l, err := xddr.Listen(xddr.TCPLocal("tcp4:%" + name + ":0"))
+		AssertNoError(t, err)
+		defer l.Close()
+
+		// Connections over the loopback are not accepted.
+		_, port, _ := strings.Cut(l.Addr().String(), ":")
+		_, err = net.Dial("tcp4", "127.0.0.1:"+port)
+		Assert(t, err != nil, "want error for connection over the loopback")
+
+		conn, err := xddr.Dial(ctx, xddr.TCPLocal("tcp4:%"+name+":"+port))
+		AssertNoError(t, err)
+		conn.Close()
+	})
+}
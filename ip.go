@@ -26,10 +26,10 @@ func (v IP) Sanitize() (IP, error) {
 	case s == "":
 		// Unspecified IP
 		return "", nil
-	case strings.Contains(s, "."):
-		return transWithErr[IP](IPv4(s).Sanitize())
 	case strings.Contains(s, ":"):
 		return transWithErr[IP](IPv6(s).Sanitize())
+	case strings.Contains(s, "."):
+		return transWithErr[IP](IPv4(s).Sanitize())
 	default:
 		return "", errors.New("invalid IP address")
 	}
@@ -39,7 +39,7 @@ func (v IP) V4() (IPv4, bool) {
 	if v == "" {
 		return "0.0.0.0", true
 	}
-	if strings.Contains(string(v), ".") {
+	if strings.Contains(string(v), ".") && !strings.Contains(string(v), ":") {
 		return IPv4(v), true
 	}
 	return "", false
@@ -55,6 +55,14 @@ func (v IP) V6() (IPv6, bool) {
 	return "", false
 }
 
+// Zone returns the zone identifier of the IPv6 address, if any.
+func (v IP) Zone() string {
+	if ipv6, ok := v.V6(); ok {
+		return ipv6.Zone()
+	}
+	return ""
+}
+
 func (v IP) Bytes() []byte {
 	if ipv4, ok := v.V4(); ok {
 		b4 := ipv4.Bytes()
@@ -97,6 +105,14 @@ func (v IP) IsPrivate() bool {
 	return false
 }
 
+// IPPort represents an IP address and port pair.
+// IPv6 address is enclosed in square brackets.
+//
+// Examples:
+//
+//	127.0.0.1:80
+//	[::1]:80
+//	[fe80::1%eth0]:80
 type IPPort string
 
 func (v IPPort) Sanitize() (IPPort, error) {
@@ -126,7 +142,15 @@ func (v IPPort) Sanitize() (IPPort, error) {
 		return "", errors.New("port number must be between 0 and 65535")
 	}
 
-	return IPPort(string(ip) + ":" + strconv.Itoa(n)), nil
+	return ipPortOf(ip, n), nil
+}
+
+// ipPortOf joins the IP address and the port, enclosing IPv6 address in square brackets.
+func ipPortOf(ip IP, port int) IPPort {
+	if _, ok := ip.V6(); ok && ip != "" {
+		return IPPort("[" + string(ip) + "]:" + strconv.Itoa(port))
+	}
+	return IPPort(string(ip) + ":" + strconv.Itoa(port))
 }
 
 func (v IPPort) Split() (IP, int) {
@@ -135,7 +159,11 @@ func (v IPPort) Split() (IP, int) {
 
 	n, _ := strconv.Atoi(s[i+1:])
 
-	return IP(s[:i]), n
+	h := s[:i]
+	if len(h) > 1 && h[0] == '[' && h[len(h)-1] == ']' {
+		h = h[1 : len(h)-1]
+	}
+	return IP(h), n
 }
 
 func (v IPPort) IP() IP {
@@ -148,6 +176,10 @@ func (v IPPort) Port() int {
 	return port
 }
 
+func (v IPPort) Zone() string {
+	return v.IP().Zone()
+}
+
 type IPwithCIDR string
 
 func (v IPwithCIDR) Sanitize() (IPwithCIDR, error) {
@@ -169,16 +201,6 @@ func (v IPwithCIDR) Sanitize() (IPwithCIDR, error) {
 	}
 
 	switch {
-	case strings.Contains(s, "."):
-		ipv4, err := IPv4(ip).Sanitize()
-		if err != nil {
-			return "", err
-		}
-		if !(0 <= n && n <= 32) {
-			return "", errors.New("network size must be between 0 and 32 for IPv4")
-		}
-		return IPwithCIDR(string(ipv4) + "/" + strconv.Itoa(n)), nil
-
 	case strings.Contains(s, ":"):
 		ipv6, err := IPv6(ip).Sanitize()
 		if err != nil {
@@ -189,6 +211,16 @@ func (v IPwithCIDR) Sanitize() (IPwithCIDR, error) {
 		}
 		return IPwithCIDR(string(ipv6) + "/" + strconv.Itoa(n)), nil
 
+	case strings.Contains(s, "."):
+		ipv4, err := IPv4(ip).Sanitize()
+		if err != nil {
+			return "", err
+		}
+		if !(0 <= n && n <= 32) {
+			return "", errors.New("network size must be between 0 and 32 for IPv4")
+		}
+		return IPwithCIDR(string(ipv4) + "/" + strconv.Itoa(n)), nil
+
 	default:
 		return "", errors.New("invalid IP address")
 	}
@@ -216,6 +248,18 @@ func (v IPwithCIDR) IsPrivate() bool {
 	return v.IP().IsPrivate()
 }
 
+// Contains reports whether the network includes the address.
+// An address of the other family is never included and the zone is ignored.
+func (v IPwithCIDR) Contains(ip IP) bool {
+	p, n := v.Split()
+	a := p.Bytes()
+	b := ip.Bytes()
+	if len(a) == 0 || len(a) != len(b) {
+		return false
+	}
+	return hasPrefix(b, a, n)
+}
+
 type IPv4 string
 
 func (v IPv4) Sanitize() (IPv4, error) {
@@ -244,12 +288,88 @@ func (v IPv4) Sanitize() (IPv4, error) {
 	return v, nil
 }
 
+// SanitizeLegacy converts the address in a legacy notation accepted by inet_aton(3)
+// into the canonical dotted decimal notation.
+// Each part can be decimal, octal with leading "0", or hexadecimal with leading "0x",
+// and the last part fills the remaining bytes.
+// A single trailing dot is allowed as WHATWG URL Standard does.
+//
+// Examples:
+//
+//	0x7f.1        -> 127.0.0.1
+//	0177.0.0.1    -> 127.0.0.1
+//	2130706433    -> 127.0.0.1
+//	192.168.257   -> 192.168.1.1
+func (v IPv4) SanitizeLegacy() (IPv4, error) {
+	s := strings.TrimSuffix(string(v), ".")
+	if s == "" {
+		return "", errors.New("empty IPv4 address")
+	}
+
+	es := strings.SplitN(s, ".", 5)
+	if len(es) > 4 {
+		return "", fmt.Errorf("must have at most 4 fields")
+	}
+
+	var b [4]byte
+	for i, e := range es {
+		n, err := parseLegacyIPv4Part(e)
+		if err != nil {
+			return "", errPos(i, err)
+		}
+
+		if i < len(es)-1 {
+			if n > 0xff {
+				return "", errPosF(i, "must be between 0 and 255, got %d", n)
+			}
+			b[i] = byte(n)
+			continue
+		}
+
+		// The last part fills the remaining bytes.
+		r := 4 - i
+		if n >= 1<<(8*r) {
+			return "", errPosF(i, "must be less than %d, got %d", uint64(1)<<(8*r), n)
+		}
+		for j := 3; j >= i; j-- {
+			b[j] = byte(n)
+			n >>= 8
+		}
+	}
+
+	return ipv4FromBytes(b), nil
+}
+
+func parseLegacyIPv4Part(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("empty")
+	}
+
+	base := 10
+	switch {
+	case len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X'):
+		base = 16
+		s = s[2:]
+		if s == "" {
+			return 0, nil
+		}
+	case len(s) >= 2 && s[0] == '0':
+		base = 8
+		s = s[1:]
+	}
+	n, err := strconv.ParseUint(s, base, 32)
+	if err != nil {
+		return 0, errors.New("not a valid number")
+	}
+	return n, nil
+}
+
 func (v IPv4) Bytes() [4]byte {
 	s := string(v)
 
 	var b [4]byte
 	es := strings.SplitN(s, ".", 5)
-	l := max(len(es), 4)
+	l := min(len(es), 4)
 	for i := 0; i < l; i++ {
 		n, _ := strconv.Atoi(es[i])
 		b[i] = byte(n)
@@ -258,6 +378,10 @@ func (v IPv4) Bytes() [4]byte {
 	return b
 }
 
+func ipv4FromBytes(b [4]byte) IPv4 {
+	return IPv4(strconv.Itoa(int(b[0])) + "." + strconv.Itoa(int(b[1])) + "." + strconv.Itoa(int(b[2])) + "." + strconv.Itoa(int(b[3])))
+}
+
 func (v IPv4) IsUnspecified() bool {
 	return v == "0.0.0.0"
 }
@@ -284,165 +408,219 @@ func (v IPv4) IsPrivate() bool {
 	}
 }
 
+// IPv6 represents an IPv6 address with optional zone identifier.
+// Zone identifier is not percent-encoded in this form.
+//
+// Examples:
+//
+//	::1
+//	2001:db8::1
+//	fe80::1%eth0
 type IPv6 string
 
+// Sanitize validates and normalizes the IPv6 address into the form described in RFC 5952.
+// The address can be enclosed in square brackets, in which case the zone identifier
+// can be percent-encoded as described in RFC 6874, e.g. "[fe80::1%25eth0]".
 func (v IPv6) Sanitize() (IPv6, error) {
 	if v == "" {
 		return "", fmt.Errorf("empty IPv6 address")
 	}
-	if v[0] == '[' {
+
+	bracketed := v[0] == '['
+	if bracketed {
 		if v[len(v)-1] != ']' {
 			return "", fmt.Errorf("missing closing ']'")
 		}
 		v = v[1 : len(v)-1]
 	}
 
-	es := strings.SplitN(string(v), ":", 9)
-	if len(es) > 8 {
-		return "", fmt.Errorf("must have at most 8 blocks")
+	addr, zone, ok := strings.Cut(string(v), "%")
+	if !ok {
+		return sanitizeIPv6(addr)
+	}
+	if bracketed && strings.HasPrefix(zone, "25") {
+		z, err := percent_decode_all(zone[2:])
+		if err != nil {
+			return "", fmt.Errorf("invalid zone: %w", err)
+		}
+		zone = z
+	}
+	if err := validateZone(zone); err != nil {
+		return "", err
+	}
+
+	w, err := sanitizeIPv6(addr)
+	if err != nil {
+		return "", err
 	}
-	if len(es) < 3 {
-		return "", fmt.Errorf("must have at least 2 colons")
+	return w + "%" + IPv6(zone), nil
+}
+
+func validateZone(zone string) error {
+	if zone == "" {
+		return errors.New("empty zone")
 	}
+	for i := 0; i < len(zone); i++ {
+		c := zone[i]
+		if c <= ' ' || c >= 0x7f || strings.IndexByte("%[]/", c) >= 0 {
+			return fmt.Errorf("invalid character %q in zone", c)
+		}
+	}
+	return nil
+}
 
-	b := [8]uint16{}
+func sanitizeIPv6(v string) (IPv6, error) {
+	b, err := parseIPv6(v)
+	if err != nil {
+		return "", err
+	}
+	return ipv6FromBytes(b), nil
+}
 
-	i := 0 // nth block currently being processed.
-	j := 0 // nth block of input currently being processed.
-	c := 0 // current run of zero blocks.
-	l := 0 // longest run of zero blocks.
-	t := 0 // tail index of longest run of zero blocks.
-	for ; j < len(es); j++ {
-		e := es[j]
-		if e == "" {
-			if j == len(es)-1 {
-				// "::" at the end
-				if es[j-1] != "" {
-					return "", fmt.Errorf("single ':' at the end is not allowed")
-				}
+// parseIPv6 parses the textual representation of IPv6 address described in RFC 4291 §2.2,
+// including the one with an embedded IPv4 address in dotted decimal notation.
+func parseIPv6(v string) ([16]byte, error) {
+	var b [16]byte
+	if strings.Count(v, ":") < 2 {
+		return b, fmt.Errorf("must have at least 2 colons")
+	}
 
-				c++
-				break
-			}
-			if i != j {
-				return "", fmt.Errorf("only one '::' allowed")
-			}
+	head, tail, compressed := strings.Cut(v, "::")
+	if compressed && strings.Contains(tail, "::") {
+		return b, fmt.Errorf("only one '::' allowed")
+	}
+	if !compressed {
+		head = v
+	}
 
-			k := 9 - len(es) // length of current omitted zero blocks
-			if j == 0 {
-				// "::" at the beginning
-				if es[1] != "" {
-					return "", fmt.Errorf("single ':' at the beginning is not allowed")
-				}
-				j++ // skip the next empty block
-				k++ // already counted one empty block
-			}
-			i += k
-			c += k
-			continue
+	hs := splitIPv6Blocks(head)
+	ts := splitIPv6Blocks(tail)
+	if len(hs) > 0 && hs[0] == "" {
+		return b, fmt.Errorf("single ':' at the beginning is not allowed")
+	}
+	if (len(ts) > 0 && ts[len(ts)-1] == "") || (!compressed && hs[len(hs)-1] == "") {
+		return b, fmt.Errorf("single ':' at the end is not allowed")
+	}
+	if len(hs)+len(ts) > 8 {
+		return b, fmt.Errorf("must have at most 8 blocks")
+	}
+
+	hb, err := parseIPv6Blocks(hs, 0, !compressed)
+	if err != nil {
+		return b, err
+	}
+	tb, err := parseIPv6Blocks(ts, len(hs)+1, true)
+	if err != nil {
+		return b, err
+	}
+
+	n := len(hb) + len(tb)
+	switch {
+	case n > 16 || (compressed && n > 14):
+		return b, fmt.Errorf("must have at most 8 blocks")
+	case !compressed && n < 16:
+		return b, fmt.Errorf("must have 8 blocks")
+	}
+
+	copy(b[:], hb)
+	copy(b[16-len(tb):], tb)
+	return b, nil
+}
+
+func splitIPv6Blocks(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.SplitN(s, ":", 9)
+}
+
+// parseIPv6Blocks parses hex blocks into bytes.
+// If the blocks are the last part of the address, the last block can be an IPv4 address.
+func parseIPv6Blocks(es []string, pos int, last bool) ([]byte, error) {
+	bs := make([]byte, 0, len(es)*2+2)
+	for i, e := range es {
+		if e == "" {
+			return nil, errPosF(pos+i, "empty block")
 		}
-		if (b[5] == 0xffff && l == 5) || // "0:0:0:0:0:ffff:IPv4"
-			// In the case of there is omitted zero blocks like: "::ffff:IPv4"
-			(b[6] == 0xffff && l == 6) {
-			// IPv4-mapped IPv6 address
+		if last && i == len(es)-1 && strings.Contains(e, ".") {
 			if _, err := IPv4(e).Sanitize(); err != nil {
-				return "", fmt.Errorf("invalid IPv4-mapped IPv6 address: %w", err)
+				if i > 0 && strings.EqualFold(es[i-1], "ffff") {
+					return nil, fmt.Errorf("invalid IPv4-mapped IPv6 address: %w", err)
+				}
+				return nil, fmt.Errorf("invalid IPv4-embedded IPv6 address: %w", err)
 			}
 
-			return IPv6("::ffff:" + e), nil
+			b4 := IPv4(e).Bytes()
+			bs = append(bs, b4[:]...)
+			break
 		}
 		if len(e) > 4 {
-			return "", fmt.Errorf("[%d]: block too long", j)
+			return nil, errPosF(pos+i, "block too long")
 		}
 
 		n, err := strconv.ParseUint(e, 16, 16)
 		if err != nil {
-			return "", fmt.Errorf("[%d]: not a valid hex number", j)
-		}
-		if n == 0 {
-			c++
-		} else {
-			if c > l {
-				l = c
-				t = i
-				c = 0
-			}
-			b[i] = uint16(n)
+			return nil, errPosF(pos+i, "not a valid hex number")
 		}
-
-		i++
-	}
-	if c > l {
-		l = c
-		t = 8
-	}
-	if l < 2 {
-		// do not shorten
-		l = 0
-		t = 8
+		bs = append(bs, byte(n>>8), byte(n))
 	}
+	return bs, nil
+}
+
+// Zone returns the zone identifier, if any.
+func (v IPv6) Zone() string {
+	_, zone, _ := strings.Cut(string(v), "%")
+	return zone
+}
 
-	bs := make([]byte, 0, len("hhhh:hhhh:hhhh:hhhh:hhhh:hhhh:hhhh:hhhh:"))
+func (v IPv6) Bytes() [16]byte {
+	s, _, _ := strings.Cut(string(v), "%")
+	b, _ := parseIPv6(s)
+	return b
+}
 
-	// Shortening the longest run of zero blocks.
-	i = 0
-	h := t - l // head index of longest run of zero blocks.
-	if h == 0 {
-		bs = append(bs, "::"...)
-		i = l
+// ipv6FromBytes formats the address as described in RFC 5952.
+func ipv6FromBytes(b [16]byte) IPv6 {
+	if [12]byte(b[:12]) == [12]byte{10: 0xff, 11: 0xff} {
+		return IPv6("::ffff:" + ipv4FromBytes([4]byte(b[12:])))
 	}
-	for ; i < 8; i++ {
-		if i == h {
-			bs = append(bs, ':')
-			i += l - 1
+
+	// Find the longest run of zero blocks.
+	h, l := 8, 0 // head index and length of the longest run.
+	for i := 0; i < 8; {
+		if b[i*2] != 0 || b[i*2+1] != 0 {
+			i++
 			continue
 		}
-		s := fmt.Sprintf("%x:", b[i])
-		bs = append(bs, s...)
+
+		j := i
+		for j < 8 && b[j*2] == 0 && b[j*2+1] == 0 {
+			j++
+		}
+		if j-i > l {
+			h, l = i, j-i
+		}
+		i = j
 	}
-	if b[7] == 0 {
-		bs = append(bs, 'x')
+	if l < 2 {
+		// do not shorten
+		h, l = 8, 0
 	}
 
-	return IPv6(bs[:len(bs)-1]), nil
-}
-
-func (v IPv6) Bytes() [16]byte {
-	s := string(v)
-
-	es := strings.SplitN(s, ":", 9)
-
-	b := [16]byte{}
-	l := min(len(es), 8)
-	j := 0 // byte index being processed.
-	for i := range l {
-		e := es[i]
-		if e == "" {
-			if i == 0 || i == l-1 {
-				j += 2
-				continue
-			}
-			k := 9 - len(es)
-			j += (k * 2)
+	bs := make([]byte, 0, len("hhhh:hhhh:hhhh:hhhh:hhhh:hhhh:hhhh:hhhh"))
+	for i := 0; i < 8; i++ {
+		if i == h {
+			bs = append(bs, "::"...)
+			i += l - 1
 			continue
 		}
-		if i == l-1 && strings.Contains(e, ".") {
-			// IPv4-mapped IPv6 address
-			ipv4 := IPv4(e)
-			b4 := ipv4.Bytes()
-			b[10] = 0xff
-			b[11] = 0xff
-			copy(b[12:], b4[:])
-			break
+		if len(bs) > 0 && bs[len(bs)-1] != ':' {
+			bs = append(bs, ':')
 		}
-
-		n, _ := strconv.ParseUint(e, 16, 16)
-		b[j] = byte(n >> 8)
-		b[j+1] = byte(n & 0xff)
-		j += 2
+		bs = strconv.AppendUint(bs, uint64(b[i*2])<<8|uint64(b[i*2+1]), 16)
 	}
 
-	return b
+	return IPv6(bs)
 }
 
 func (v IPv6) IsLoopback() bool {
@@ -452,7 +630,7 @@ func (v IPv6) IsLoopback() bool {
 func (v IPv6) IsPrivate() bool {
 	b := v.Bytes()
 	switch {
-	case b[0]|0b11111110 == 0xfc:
+	case b[0]&0b11111110 == 0xfc:
 		// fc00::/7
 		return true
 	default:
@@ -495,6 +673,17 @@ func (x ipBaseLocal) Sanitize(v string) (string, error) {
 		addr = v
 	}
 
+	if name, port, ok := cutInterfaceHost(addr); ok {
+		if err := checkInterfaceName(name); err != nil {
+			return "", err
+		}
+		p, err := strconv.Atoi(port)
+		if err != nil || p < 0 || p > 65535 {
+			return "", errors.New("invalid port")
+		}
+		return net + ":%" + name + ":" + strconv.Itoa(p), nil
+	}
+
 	a, err := Authority(addr).Sanitize()
 	if err != nil {
 		return "", err
@@ -521,6 +710,7 @@ func (x ipBaseLocal) Sanitize(v string) (string, error) {
 			return "", errors.New("invalid local address: IPv6 address with IPv4 network")
 		}
 		net = net6
+		h = h.raw()
 	default:
 		// unreachable?
 		return "", errors.New("invalid local address: host is not an IP address")
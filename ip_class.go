@@ -0,0 +1,530 @@
+package xddr
+
+// IPClass is a classification of an IP address according to
+// the IANA IPv4 and IPv6 Special-Purpose Address Registries (RFC 6890).
+type IPClass int
+
+const (
+	// IPClassInvalid is returned for a value that is not an IP address.
+	IPClassInvalid IPClass = iota
+
+	IPClassUnspecified        // 0.0.0.0/32, ::/128
+	IPClassThisNetwork        // 0.0.0.0/8
+	IPClassLoopback           // 127.0.0.0/8, ::1/128
+	IPClassPrivate            // 10.0.0.0/8, 172.16.0.0/12, 192.168.0.0/16
+	IPClassShared             // 100.64.0.0/10 (Carrier-Grade NAT)
+	IPClassLinkLocal          // 169.254.0.0/16, fe80::/10
+	IPClassProtocolAssignment // 192.0.0.0/24, 2001::/23
+	IPClassDocumentation      // 192.0.2.0/24, 198.51.100.0/24, 203.0.113.0/24, 2001:db8::/32
+	IPClassBenchmarking       // 198.18.0.0/15, 2001:2::/48
+	IPClass6to4               // 192.88.99.0/24, 2002::/16
+	IPClassTeredo             // 2001::/32
+	IPClassIPv4Mapped         // ::ffff:0:0/96
+	IPClassNAT64              // 64:ff9b::/96
+	IPClassDiscardOnly        // 100::/64
+	IPClassUniqueLocal        // fc00::/7
+	IPClassMulticast          // 224.0.0.0/4, ff00::/8
+	IPClassBroadcast          // 255.255.255.255/32
+	IPClassReserved           // 240.0.0.0/4 and unassigned IPv6 space
+	IPClassGlobalUnicast      // anything else in 0.0.0.0/0 and 2000::/3
+)
+
+func (c IPClass) String() string {
+	switch c {
+	case IPClassUnspecified:
+		return "unspecified"
+	case IPClassThisNetwork:
+		return "this-network"
+	case IPClassLoopback:
+		return "loopback"
+	case IPClassPrivate:
+		return "private"
+	case IPClassShared:
+		return "shared"
+	case IPClassLinkLocal:
+		return "link-local"
+	case IPClassProtocolAssignment:
+		return "protocol-assignment"
+	case IPClassDocumentation:
+		return "documentation"
+	case IPClassBenchmarking:
+		return "benchmarking"
+	case IPClass6to4:
+		return "6to4"
+	case IPClassTeredo:
+		return "teredo"
+	case IPClassIPv4Mapped:
+		return "ipv4-mapped"
+	case IPClassNAT64:
+		return "nat64"
+	case IPClassDiscardOnly:
+		return "discard-only"
+	case IPClassUniqueLocal:
+		return "unique-local"
+	case IPClassMulticast:
+		return "multicast"
+	case IPClassBroadcast:
+		return "broadcast"
+	case IPClassReserved:
+		return "reserved"
+	case IPClassGlobalUnicast:
+		return "global-unicast"
+	default:
+		return "invalid"
+	}
+}
+
+// MulticastScope is a scope of a multicast address.
+// Values are the same as the scop field of IPv6 multicast address (RFC 7346).
+type MulticastScope int
+
+const (
+	MulticastScopeInterfaceLocal    MulticastScope = 0x1
+	MulticastScopeLinkLocal         MulticastScope = 0x2
+	MulticastScopeRealmLocal        MulticastScope = 0x3
+	MulticastScopeAdminLocal        MulticastScope = 0x4
+	MulticastScopeSiteLocal         MulticastScope = 0x5
+	MulticastScopeOrganizationLocal MulticastScope = 0x8
+	MulticastScopeGlobal            MulticastScope = 0xe
+)
+
+type ipClassEntry struct {
+	prefix []byte
+	bits   int
+	class  IPClass
+}
+
+// More specific entries must come first since the first match wins.
+var ipv4ClassTable = []ipClassEntry{
+	{[]byte{0, 0, 0, 0}, 32, IPClassUnspecified},
+	{[]byte{255, 255, 255, 255}, 32, IPClassBroadcast},
+	{[]byte{0}, 8, IPClassThisNetwork},
+	{[]byte{10}, 8, IPClassPrivate},
+	{[]byte{100, 64}, 10, IPClassShared},
+	{[]byte{127}, 8, IPClassLoopback},
+	{[]byte{169, 254}, 16, IPClassLinkLocal},
+	{[]byte{172, 16}, 12, IPClassPrivate},
+	{[]byte{192, 0, 0}, 24, IPClassProtocolAssignment},
+	{[]byte{192, 0, 2}, 24, IPClassDocumentation},
+	{[]byte{192, 88, 99}, 24, IPClass6to4},
+	{[]byte{192, 168}, 16, IPClassPrivate},
+	{[]byte{198, 18}, 15, IPClassBenchmarking},
+	{[]byte{198, 51, 100}, 24, IPClassDocumentation},
+	{[]byte{203, 0, 113}, 24, IPClassDocumentation},
+	{[]byte{224}, 4, IPClassMulticast},
+	{[]byte{240}, 4, IPClassReserved},
+}
+
+var ipv6ClassTable = []ipClassEntry{
+	{[]byte{}, 128, IPClassUnspecified},
+	{[]byte{15: 1}, 128, IPClassLoopback},
+	{[]byte{10: 0xff, 11: 0xff}, 96, IPClassIPv4Mapped},
+	{[]byte{0x00, 0x64, 0xff, 0x9b}, 96, IPClassNAT64},
+	{[]byte{0x01, 0x00}, 64, IPClassDiscardOnly},
+	{[]byte{0x20, 0x01, 0x00, 0x00}, 32, IPClassTeredo},
+	{[]byte{0x20, 0x01, 0x00, 0x02, 0x00, 0x00}, 48, IPClassBenchmarking},
+	{[]byte{0x20, 0x01, 0x0d, 0xb8}, 32, IPClassDocumentation},
+	{[]byte{0x20, 0x01}, 23, IPClassProtocolAssignment},
+	{[]byte{0x20, 0x02}, 16, IPClass6to4},
+	{[]byte{0xfc}, 7, IPClassUniqueLocal},
+	{[]byte{0xfe, 0x80}, 10, IPClassLinkLocal},
+	{[]byte{0xff}, 8, IPClassMulticast},
+	{[]byte{0x20}, 3, IPClassGlobalUnicast},
+}
+
+// hasPrefix reports whether the first n bits of b are equal to the ones of p.
+// Bytes of p beyond its length are treated as zero.
+func hasPrefix(b []byte, p []byte, n int) bool {
+	for i := 0; n > 0; i++ {
+		var c byte
+		if i < len(p) {
+			c = p[i]
+		}
+
+		m := byte(0xff)
+		if n < 8 {
+			m <<= 8 - n
+		}
+		if b[i]&m != c&m {
+			return false
+		}
+		n -= 8
+	}
+	return true
+}
+
+func classify(b []byte, table []ipClassEntry, fallback IPClass) IPClass {
+	for _, e := range table {
+		if hasPrefix(b, e.prefix, e.bits) {
+			return e.class
+		}
+	}
+	return fallback
+}
+
+// Class classifies the address. Note that an IPv4-mapped IPv6 address is
+// classified as [IPClassIPv4Mapped] regardless of the embedded IPv4 address.
+func (v IP) Class() IPClass {
+	if ipv4, ok := v.V4(); ok {
+		return ipv4.Class()
+	}
+	if ipv6, ok := v.V6(); ok {
+		return ipv6.Class()
+	}
+	return IPClassInvalid
+}
+
+func (v IP) MulticastScope() (MulticastScope, bool) {
+	if ipv4, ok := v.V4(); ok {
+		return ipv4.MulticastScope()
+	}
+	if ipv6, ok := v.V6(); ok {
+		return ipv6.MulticastScope()
+	}
+	return 0, false
+}
+
+func (v IP) IsThisNetwork() bool {
+	return v.Class() == IPClassThisNetwork
+}
+
+func (v IP) IsShared() bool {
+	return v.Class() == IPClassShared
+}
+
+func (v IP) IsLinkLocal() bool {
+	return v.Class() == IPClassLinkLocal
+}
+
+func (v IP) IsProtocolAssignment() bool {
+	return v.Class() == IPClassProtocolAssignment
+}
+
+func (v IP) IsDocumentation() bool {
+	return v.Class() == IPClassDocumentation
+}
+
+func (v IP) IsBenchmarking() bool {
+	return v.Class() == IPClassBenchmarking
+}
+
+func (v IP) Is6to4() bool {
+	return v.Class() == IPClass6to4
+}
+
+func (v IP) IsTeredo() bool {
+	return v.Class() == IPClassTeredo
+}
+
+func (v IP) IsUniqueLocal() bool {
+	return v.Class() == IPClassUniqueLocal
+}
+
+func (v IP) IsMulticast() bool {
+	return v.Class() == IPClassMulticast
+}
+
+func (v IP) IsBroadcast() bool {
+	return v.Class() == IPClassBroadcast
+}
+
+func (v IP) IsReserved() bool {
+	return v.Class() == IPClassReserved
+}
+
+func (v IP) IsGlobalUnicast() bool {
+	return v.Class() == IPClassGlobalUnicast
+}
+
+func (v IPv4) Class() IPClass {
+	b := v.Bytes()
+	return classify(b[:], ipv4ClassTable, IPClassGlobalUnicast)
+}
+
+// MulticastScope returns scope of the multicast address.
+// Scopes of IPv4 multicast addresses are mapped to the IPv6 ones as described in RFC 2365.
+func (v IPv4) MulticastScope() (MulticastScope, bool) {
+	b := v.Bytes()
+	switch {
+	case b[0]&0xf0 != 224:
+		return 0, false
+	case b[0] == 224 && b[1] == 0 && b[2] == 0:
+		// 224.0.0.0/24
+		return MulticastScopeLinkLocal, true
+	case b[0] == 239 && b[1] == 255:
+		// 239.255.0.0/16
+		return MulticastScopeSiteLocal, true
+	case b[0] == 239 && b[1]&0xfc == 192:
+		// 239.192.0.0/14
+		return MulticastScopeOrganizationLocal, true
+	case b[0] == 239:
+		// 239.0.0.0/8
+		return MulticastScopeAdminLocal, true
+	default:
+		return MulticastScopeGlobal, true
+	}
+}
+
+func (v IPv4) IsThisNetwork() bool {
+	return v.Class() == IPClassThisNetwork
+}
+
+func (v IPv4) IsShared() bool {
+	return v.Class() == IPClassShared
+}
+
+func (v IPv4) IsLinkLocal() bool {
+	return v.Class() == IPClassLinkLocal
+}
+
+func (v IPv4) IsProtocolAssignment() bool {
+	return v.Class() == IPClassProtocolAssignment
+}
+
+func (v IPv4) IsDocumentation() bool {
+	return v.Class() == IPClassDocumentation
+}
+
+func (v IPv4) IsBenchmarking() bool {
+	return v.Class() == IPClassBenchmarking
+}
+
+func (v IPv4) Is6to4() bool {
+	return v.Class() == IPClass6to4
+}
+
+func (v IPv4) IsMulticast() bool {
+	return v.Class() == IPClassMulticast
+}
+
+func (v IPv4) IsBroadcast() bool {
+	return v.Class() == IPClassBroadcast
+}
+
+func (v IPv4) IsReserved() bool {
+	return v.Class() == IPClassReserved
+}
+
+func (v IPv4) IsGlobalUnicast() bool {
+	return v.Class() == IPClassGlobalUnicast
+}
+
+func (v IPv6) Class() IPClass {
+	b := v.Bytes()
+	return classify(b[:], ipv6ClassTable, IPClassReserved)
+}
+
+func (v IPv6) MulticastScope() (MulticastScope, bool) {
+	b := v.Bytes()
+	if b[0] != 0xff {
+		return 0, false
+	}
+	return MulticastScope(b[1] & 0x0f), true
+}
+
+func (v IPv6) IsLinkLocal() bool {
+	return v.Class() == IPClassLinkLocal
+}
+
+func (v IPv6) IsProtocolAssignment() bool {
+	return v.Class() == IPClassProtocolAssignment
+}
+
+func (v IPv6) IsDocumentation() bool {
+	return v.Class() == IPClassDocumentation
+}
+
+func (v IPv6) IsBenchmarking() bool {
+	return v.Class() == IPClassBenchmarking
+}
+
+func (v IPv6) Is6to4() bool {
+	return v.Class() == IPClass6to4
+}
+
+func (v IPv6) IsTeredo() bool {
+	return v.Class() == IPClassTeredo
+}
+
+func (v IPv6) IsUniqueLocal() bool {
+	return v.Class() == IPClassUniqueLocal
+}
+
+func (v IPv6) IsMulticast() bool {
+	return v.Class() == IPClassMulticast
+}
+
+func (v IPv6) IsReserved() bool {
+	return v.Class() == IPClassReserved
+}
+
+func (v IPv6) IsGlobalUnicast() bool {
+	return v.Class() == IPClassGlobalUnicast
+}
+
+// Class classifies the host if it is an IP address.
+// It returns [IPClassInvalid] for a domain.
+func (v Host) Class() IPClass {
+	ip, ok := v.IP()
+	if !ok {
+		return IPClassInvalid
+	}
+	return ip.Class()
+}
+
+func (v Host) MulticastScope() (MulticastScope, bool) {
+	ip, ok := v.IP()
+	if !ok {
+		return 0, false
+	}
+	return ip.MulticastScope()
+}
+
+func (v Host) IsUnspecified() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsUnspecified()
+}
+
+func (v Host) IsLoopback() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsLoopback()
+}
+
+func (v Host) IsPrivate() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsPrivate()
+}
+
+func (v Host) IsThisNetwork() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsThisNetwork()
+}
+
+func (v Host) IsShared() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsShared()
+}
+
+func (v Host) IsLinkLocal() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsLinkLocal()
+}
+
+func (v Host) IsProtocolAssignment() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsProtocolAssignment()
+}
+
+func (v Host) IsDocumentation() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsDocumentation()
+}
+
+func (v Host) IsBenchmarking() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsBenchmarking()
+}
+
+func (v Host) Is6to4() bool {
+	ip, ok := v.IP()
+	return ok && ip.Is6to4()
+}
+
+func (v Host) IsTeredo() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsTeredo()
+}
+
+func (v Host) IsUniqueLocal() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsUniqueLocal()
+}
+
+func (v Host) IsMulticast() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsMulticast()
+}
+
+func (v Host) IsBroadcast() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsBroadcast()
+}
+
+func (v Host) IsReserved() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsReserved()
+}
+
+func (v Host) IsGlobalUnicast() bool {
+	ip, ok := v.IP()
+	return ok && ip.IsGlobalUnicast()
+}
+
+// Class classifies the IP address part of the CIDR notation.
+func (v IPwithCIDR) Class() IPClass {
+	return v.IP().Class()
+}
+
+func (v IPwithCIDR) MulticastScope() (MulticastScope, bool) {
+	return v.IP().MulticastScope()
+}
+
+func (v IPwithCIDR) IsUnspecified() bool {
+	return v.IP().IsUnspecified()
+}
+
+func (v IPwithCIDR) IsLoopback() bool {
+	return v.IP().IsLoopback()
+}
+
+func (v IPwithCIDR) IsThisNetwork() bool {
+	return v.IP().IsThisNetwork()
+}
+
+func (v IPwithCIDR) IsShared() bool {
+	return v.IP().IsShared()
+}
+
+func (v IPwithCIDR) IsLinkLocal() bool {
+	return v.IP().IsLinkLocal()
+}
+
+func (v IPwithCIDR) IsProtocolAssignment() bool {
+	return v.IP().IsProtocolAssignment()
+}
+
+func (v IPwithCIDR) IsDocumentation() bool {
+	return v.IP().IsDocumentation()
+}
+
+func (v IPwithCIDR) IsBenchmarking() bool {
+	return v.IP().IsBenchmarking()
+}
+
+func (v IPwithCIDR) Is6to4() bool {
+	return v.IP().Is6to4()
+}
+
+func (v IPwithCIDR) IsTeredo() bool {
+	return v.IP().IsTeredo()
+}
+
+func (v IPwithCIDR) IsUniqueLocal() bool {
+	return v.IP().IsUniqueLocal()
+}
+
+func (v IPwithCIDR) IsMulticast() bool {
+	return v.IP().IsMulticast()
+}
+
+func (v IPwithCIDR) IsBroadcast() bool {
+	return v.IP().IsBroadcast()
+}
+
+func (v IPwithCIDR) IsReserved() bool {
+	return v.IP().IsReserved()
+}
+
+func (v IPwithCIDR) IsGlobalUnicast() bool {
+	return v.IP().IsGlobalUnicast()
+}
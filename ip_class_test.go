@@ -0,0 +1,119 @@
+package xddr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestIPClass(t *testing.T) {
+	t.Run("Class", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.IP
+			want  xddr.IPClass
+		}{
+			{"", xddr.IPClassUnspecified},
+			{"0.0.0.0", xddr.IPClassUnspecified},
+			{"0.1.2.3", xddr.IPClassThisNetwork},
+			{"10.1.2.3", xddr.IPClassPrivate},
+			{"100.64.0.1", xddr.IPClassShared},
+			{"100.127.255.255", xddr.IPClassShared},
+			{"100.128.0.1", xddr.IPClassGlobalUnicast},
+			{"127.0.0.1", xddr.IPClassLoopback},
+			{"169.254.1.1", xddr.IPClassLinkLocal},
+			{"172.16.0.1", xddr.IPClassPrivate},
+			{"172.31.255.255", xddr.IPClassPrivate},
+			{"172.32.0.1", xddr.IPClassGlobalUnicast},
+			{"192.0.0.8", xddr.IPClassProtocolAssignment},
+			{"192.0.2.1", xddr.IPClassDocumentation},
+			{"192.88.99.1", xddr.IPClass6to4},
+			{"192.168.0.1", xddr.IPClassPrivate},
+			{"198.18.0.1", xddr.IPClassBenchmarking},
+			{"198.19.255.255", xddr.IPClassBenchmarking},
+			{"198.51.100.1", xddr.IPClassDocumentation},
+			{"203.0.113.1", xddr.IPClassDocumentation},
+			{"224.0.0.1", xddr.IPClassMulticast},
+			{"239.255.255.250", xddr.IPClassMulticast},
+			{"240.0.0.1", xddr.IPClassReserved},
+			{"255.255.255.255", xddr.IPClassBroadcast},
+			{"8.8.8.8", xddr.IPClassGlobalUnicast},
+			{"::", xddr.IPClassUnspecified},
+			{"::1", xddr.IPClassLoopback},
+			{"::ffff:192.0.2.1", xddr.IPClassIPv4Mapped},
+			{"64:ff9b::c000:201", xddr.IPClassNAT64},
+			{"100::1", xddr.IPClassDiscardOnly},
+			{"2001::1", xddr.IPClassTeredo},
+			{"2001:2::1", xddr.IPClassBenchmarking},
+			{"2001:db8::1", xddr.IPClassDocumentation},
+			{"2001:10::1", xddr.IPClassProtocolAssignment},
+			{"2001:200::1", xddr.IPClassGlobalUnicast},
+			{"2002:c000:201::1", xddr.IPClass6to4},
+			{"fc00::1", xddr.IPClassUniqueLocal},
+			{"fd12:3456::1", xddr.IPClassUniqueLocal},
+			{"fe80::1", xddr.IPClassLinkLocal},
+			{"febf::1", xddr.IPClassLinkLocal},
+			{"fec0::1", xddr.IPClassReserved},
+			{"ff02::1", xddr.IPClassMulticast},
+			{"2606:4700::1111", xddr.IPClassGlobalUnicast},
+			{"4000::1", xddr.IPClassReserved},
+		} {
+			t.Run(fmt.Sprintf("IP(%q).Class()=%s", tc.given, tc.want), func(t *testing.T) {
+				AssertEq(t, tc.given.Class(), tc.want)
+			})
+		}
+	})
+	t.Run("MulticastScope", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.IP
+			want  xddr.MulticastScope
+		}{
+			{"224.0.0.251", xddr.MulticastScopeLinkLocal},
+			{"224.0.1.1", xddr.MulticastScopeGlobal},
+			{"239.1.2.3", xddr.MulticastScopeAdminLocal},
+			{"239.192.0.1", xddr.MulticastScopeOrganizationLocal},
+			{"239.255.255.250", xddr.MulticastScopeSiteLocal},
+			{"ff01::1", xddr.MulticastScopeInterfaceLocal},
+			{"ff02::fb", xddr.MulticastScopeLinkLocal},
+			{"ff05::2", xddr.MulticastScopeSiteLocal},
+			{"ff0e::1", xddr.MulticastScopeGlobal},
+		} {
+			t.Run(fmt.Sprintf("IP(%q).MulticastScope()=%d", tc.given, tc.want), func(t *testing.T) {
+				v, ok := tc.given.MulticastScope()
+				Assert(t, ok, "want multicast")
+				AssertEq(t, v, tc.want)
+			})
+		}
+		for _, given := range []xddr.IP{"127.0.0.1", "fe80::1"} {
+			t.Run(fmt.Sprintf("IP(%q).MulticastScope() -> not multicast", given), func(t *testing.T) {
+				_, ok := given.MulticastScope()
+				Assert(t, !ok, "want not multicast")
+			})
+		}
+	})
+	t.Run("Host", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.Host
+			want  xddr.IPClass
+		}{
+			{"127.0.0.1", xddr.IPClassLoopback},
+			{"[::1]", xddr.IPClassLoopback},
+			{"[fe80::1]", xddr.IPClassLinkLocal},
+			{"100.64.0.1", xddr.IPClassShared},
+			{"localhost", xddr.IPClassInvalid},
+		} {
+			t.Run(fmt.Sprintf("Host(%q).Class()=%s", tc.given, tc.want), func(t *testing.T) {
+				AssertEq(t, tc.given.Class(), tc.want)
+			})
+		}
+		Assert(t, xddr.Host("[fd00::1]").IsPrivate(), "want private")
+		Assert(t, xddr.Host("[fe80::1]").IsLinkLocal(), "want link-local")
+		Assert(t, !xddr.Host("example.com").IsLoopback(), "want not loopback")
+	})
+	t.Run("IPwithCIDR", func(t *testing.T) {
+		Assert(t, xddr.IPwithCIDR("100.64.0.0/10").IsShared(), "want shared")
+		Assert(t, xddr.IPwithCIDR("fe80::/10").IsLinkLocal(), "want link-local")
+		Assert(t, xddr.IPwithCIDR("2001:db8::/32").IsDocumentation(), "want documentation")
+		AssertEq(t, xddr.IPwithCIDR("ff02::/16").Class(), xddr.IPClassMulticast)
+	})
+}
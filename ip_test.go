@@ -41,6 +41,48 @@ func TestIPv4(t *testing.T) {
 			})
 		}
 	})
+	t.Run("SanitizeLegacy", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.IPv4
+			want  xddr.IPv4
+		}{
+			{"127.0.0.1", "127.0.0.1"},
+			{"0x7f.1", "127.0.0.1"},
+			{"0X7F.0.0.1", "127.0.0.1"},
+			{"0177.0.0.1", "127.0.0.1"},
+			{"2130706433", "127.0.0.1"},
+			{"0x7f000001", "127.0.0.1"},
+			{"192.168.257", "192.168.1.1"},
+			{"10.0x10203", "10.1.2.3"},
+			{"0x.0.0.0", "0.0.0.0"},
+			{"127.1.", "127.0.0.1"},
+		} {
+			t.Run(fmt.Sprintf("IPv4(%q).SanitizeLegacy()=%q", tc.given, tc.want), func(t *testing.T) {
+				v, err := tc.given.SanitizeLegacy()
+				AssertNoError(t, err)
+				AssertEq(t, v, tc.want)
+			})
+		}
+		for _, tc := range []struct {
+			given xddr.IPv4
+			err   string
+		}{
+			{"", "empty"},
+			{"1.2.3.4.5", "must have at most 4 fields"},
+			{"1..2", "[1]: empty"},
+			{"08.0.0.1", "[0]: not a valid number"},
+			{"0xg.0.0.1", "[0]: not a valid number"},
+			{"+1.0.0.1", "[0]: not a valid number"},
+			{"256.0.0.1", "[0]: must be between 0 and 255"},
+			{"1.2.65536", "[2]: must be less than 65536"},
+			{"4294967296", "[0]: not a valid number"},
+		} {
+			t.Run(fmt.Sprintf("IPv4(%q).SanitizeLegacy() -> %q", tc.given, tc.err), func(t *testing.T) {
+				_, err := tc.given.SanitizeLegacy()
+				AssertErrorContains(t, err, tc.err)
+			})
+		}
+	})
 	t.Run("Bytes", func(t *testing.T) {
 		for _, tc := range []struct {
 			given xddr.IPv4
@@ -160,6 +202,46 @@ func TestIPv6(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Zone", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.IPv6
+			want  xddr.IPv6
+			zone  string
+		}{
+			{"fe80::1%eth0", "fe80::1%eth0", "eth0"},
+			{"FE80:0:0:0:0:0:0:1%eth0.100", "fe80::1%eth0.100", "eth0.100"},
+			{"[fe80::1%eth0]", "fe80::1%eth0", "eth0"},
+			{"[fe80::1%25eth0]", "fe80::1%eth0", "eth0"},
+			{"[fe80::1%25en%30]", "fe80::1%en0", "en0"},
+			{"ff02::1%3", "ff02::1%3", "3"},
+		} {
+			t.Run(fmt.Sprintf("IPv6(%q).Sanitize()=%q", tc.given, tc.want), func(t *testing.T) {
+				value, err := tc.given.Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, value, tc.want)
+				AssertEq(t, value.Zone(), tc.zone)
+			})
+		}
+		for _, tc := range [][]string{
+			{"empty zone",
+				"fe80::1%",
+				"[fe80::1%25]",
+			},
+			{"invalid character",
+				"fe80::1%eth 0",
+				"fe80::1%eth/0",
+				"[fe80::1%25eth%250]",
+			},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("IPv6(%q).Sanitize() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.IPv6(given).Sanitize()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+		AssertEq(t, xddr.IPv6("fe80::1%eth0").Bytes(), xddr.IPv6("fe80::1").Bytes())
+	})
 	t.Run("Bytes", func(t *testing.T) {
 		for _, tc := range []struct {
 			given xddr.IPv6
@@ -180,3 +262,51 @@ func TestIPv6(t *testing.T) {
 		}
 	})
 }
+
+func TestIP(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.IP
+			want  xddr.IP
+		}{
+			{"", ""},
+			{"127.0.0.1", "127.0.0.1"},
+			{"0:0::1", "::1"},
+			{"::ffff:192.0.2.128", "::ffff:192.0.2.128"},
+			{"fe80::1%eth0.100", "fe80::1%eth0.100"},
+		} {
+			t.Run(fmt.Sprintf("IP(%q).Sanitize()=%q", tc.given, tc.want), func(t *testing.T) {
+				value, err := tc.given.Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, value, tc.want)
+			})
+		}
+	})
+}
+
+func TestIPPort(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.IPPort
+			want  xddr.IPPort
+			ip    xddr.IP
+			port  int
+			zone  string
+		}{
+			{"127.0.0.1:80", "127.0.0.1:80", "127.0.0.1", 80, ""},
+			{"[::1]:80", "[::1]:80", "::1", 80, ""},
+			{"[0::1]:080", "[::1]:80", "::1", 80, ""},
+			{"[fe80::1%eth0]:80", "[fe80::1%eth0]:80", "fe80::1%eth0", 80, "eth0"},
+			{"[fe80::1%25eth0]:80", "[fe80::1%eth0]:80", "fe80::1%eth0", 80, "eth0"},
+		} {
+			t.Run(fmt.Sprintf("IPPort(%q).Sanitize()=%q", tc.given, tc.want), func(t *testing.T) {
+				value, err := tc.given.Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, value, tc.want)
+				AssertEq(t, value.IP(), tc.ip)
+				AssertEq(t, value.Port(), tc.port)
+				AssertEq(t, value.Zone(), tc.zone)
+			})
+		}
+	})
+}
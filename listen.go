@@ -0,0 +1,281 @@
+package xddr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// ListenConfig contains options for listening on a local address.
+//
+// The zero value listens as [net.Listen] does.
+type ListenConfig struct {
+	// ListenConfig to create listeners.
+	// The zero [net.ListenConfig] is used if nil.
+	ListenConfig *net.ListenConfig
+
+	// Set SO_REUSEADDR on the socket.
+	// Listening fails with an error wrapping [errors.ErrUnsupported]
+	// on platforms without it, such as Windows.
+	ReuseAddr bool
+
+	// Set SO_REUSEPORT on the socket, so multiple processes can listen on the same port.
+	// Listening fails with an error wrapping [errors.ErrUnsupported]
+	// on platforms without it, such as Windows.
+	ReusePort bool
+
+	// Mode of the unix socket file, left as created if zero.
+	// It is applied after the socket is bound, so the file has the mode given by the umask
+	// until then; put the socket in a directory which is not accessible by others
+	// if connections in between must be prevented.
+	Mode os.FileMode
+
+	// Owner of the unix socket file, left as created if nil.
+	// It is applied after the socket is bound, as Mode is.
+	Owner *UnixOwner
+
+	// Remove the unix socket file left by a previous process before listening.
+	// The file is removed only if it is a socket and no process accepts connections on it.
+	RemoveStale bool
+
+	// Create parent directories of the unix socket file with mode 0755 if they do not exist.
+	MkdirAll bool
+
+	// Keep the unix socket file on Close.
+	// By default, the file is removed on Close as [net.UnixListener] does.
+	KeepOnClose bool
+}
+
+// UnixOwner is an owner of a unix socket file.
+// An ID of -1 leaves it unchanged as [os.Chown] does.
+type UnixOwner struct {
+	UID int
+	GID int
+}
+
+func (c *ListenConfig) listenConfig() net.ListenConfig {
+	var lc net.ListenConfig
+	if c.ListenConfig != nil {
+		lc = *c.ListenConfig
+	}
+	if !c.ReuseAddr && !c.ReusePort {
+		return lc
+	}
+
+	control := lc.Control
+	lc.Control = func(network, address string, conn syscall.RawConn) error {
+		if control != nil {
+			if err := control(network, address, conn); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if cerr := conn.Control(func(fd uintptr) {
+			err = setReuse(fd, c.ReuseAddr, c.ReusePort)
+		}); cerr != nil {
+			return cerr
+		}
+		return err
+	}
+	return lc
+}
+
+func isUnixNetwork(network string) bool {
+	switch network {
+	case "unix", "unixpacket", "unixgram":
+		return true
+	default:
+		return false
+	}
+}
+
+// isUnixFile reports whether the address is a unix socket file,
+// which is not in the abstract namespace.
+func isUnixFile(network, address string) bool {
+	return isUnixNetwork(network) && address != "" && address[0] != '@'
+}
+
+// prepareUnix prepares the socket file path before listening.
+func (c *ListenConfig) prepareUnix(network, path string) error {
+	if c.MkdirAll {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+	}
+	if c.RemoveStale {
+		if err := removeStaleSocket(network, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finishUnix applies the options to the socket file after listening.
+func (c *ListenConfig) finishUnix(path string) error {
+	if c.Mode != 0 {
+		if err := os.Chmod(path, c.Mode); err != nil {
+			return err
+		}
+	}
+	if c.Owner != nil {
+		if err := os.Chown(path, c.Owner.UID, c.Owner.GID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStaleSocket removes the socket file if no one accepts connections on it.
+func removeStaleSocket(network, path string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		// Not a socket, let the listen fail.
+		return nil
+	}
+
+	conn, err := net.Dial(network, path)
+	if err == nil {
+		// Someone is listening, let the listen fail.
+		conn.Close()
+		return nil
+	}
+	if !isConnRefused(err) {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Listen listens on the address as [net.ListenConfig.Listen] does with the options applied.
+// The network "fd" or "systemd" uses the inherited socket as [FDLocal] describes, where the options are not applied.
+// The network "vsock" listens on AF_VSOCK socket as [VsockLocal] describes, which is supported only on Linux.
+// The network "mem" listens in the process as [MemLocal] describes.
+// The address "%<interface>:<port>" listens on the interface using SO_BINDTODEVICE on Linux
+// or on the address of the interface otherwise.
+func (c *ListenConfig) Listen(ctx context.Context, network, address string) (net.Listener, error) {
+	if isFDNetwork(network) {
+		return listenFD(network, address)
+	}
+	if network == "vsock" {
+		return listenVsockAt(ctx, address)
+	}
+	if network == "mem" {
+		return listenMem(address)
+	}
+	if err := checkReuse(c.ReuseAddr, c.ReusePort); err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+	if name, port, ok := cutInterface(address); ok && isIPNetwork(network) {
+		return c.listenInterface(ctx, network, name, port)
+	}
+	network, address = netAddrOf(network, address)
+
+	unix := isUnixFile(network, address)
+	if unix {
+		if err := c.prepareUnix(network, address); err != nil {
+			return nil, err
+		}
+	}
+
+	lc := c.listenConfig()
+	l, err := lc.Listen(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	if !unix {
+		return l, nil
+	}
+
+	if err := c.finishUnix(address); err != nil {
+		l.Close()
+		return nil, err
+	}
+	if c.KeepOnClose {
+		keepUnixFile(l)
+	}
+	return l, nil
+}
+
+// ListenPacket listens on the address as [net.ListenConfig.ListenPacket] does with the options applied.
+// Note that the unix socket file of "unixgram" network is not removed on Close.
+func (c *ListenConfig) ListenPacket(ctx context.Context, network, address string) (net.PacketConn, error) {
+	if isFDNetwork(network) {
+		return listenPacketFD(network, address)
+	}
+	if err := checkReuse(c.ReuseAddr, c.ReusePort); err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+	if name, port, ok := cutInterface(address); ok && isIPNetwork(network) {
+		return c.listenPacketInterface(ctx, network, name, port)
+	}
+	network, address = netAddrOf(network, address)
+
+	unix := isUnixFile(network, address)
+	if unix {
+		if err := c.prepareUnix(network, address); err != nil {
+			return nil, err
+		}
+	}
+
+	lc := c.listenConfig()
+	conn, err := lc.ListenPacket(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	if !unix {
+		return conn, nil
+	}
+
+	if err := c.finishUnix(address); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func listenVsockAt(ctx context.Context, address string) (net.Listener, error) {
+	a, err := parseVsockAddr(address)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: "vsock", Err: err}
+	}
+	l, err := listenVsock(ctx, a)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: "vsock", Addr: a, Err: err}
+	}
+	return l, nil
+}
+
+// ListenWith listens on the local address with the config.
+func ListenWith[T LocalLike](ctx context.Context, c *ListenConfig, v T) (*Bound, error) {
+	n, a := Local(v).Split()
+	l, err := c.Listen(ctx, n, a)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := boundWith(l, n)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// ListenPacketWith listens on the local address with the config.
+func ListenPacketWith[T LocalLike](ctx context.Context, c *ListenConfig, v T) (net.PacketConn, error) {
+	n, a := Local(v).Split()
+	return c.ListenPacket(ctx, n, a)
+}
@@ -0,0 +1,153 @@
+package xddr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// MultiListener is a listener which accepts connections from multiple listeners.
+// Closing it closes all of the listeners.
+type MultiListener struct {
+	bounds []*Bound
+
+	conns chan acceptResult
+	done  chan struct{}
+	once  sync.Once
+	wg    sync.WaitGroup
+	err   error
+}
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+// ListenAll listens on all of the local addresses using the zero [ListenConfig].
+func ListenAll(vs []Local) (*MultiListener, error) {
+	return ListenAllWith(context.Background(), &ListenConfig{}, vs)
+}
+
+// ListenAllWith listens on all of the local addresses with the config.
+// Listeners already opened are closed if any of them fails.
+func ListenAllWith(ctx context.Context, c *ListenConfig, vs []Local) (*MultiListener, error) {
+	if len(vs) == 0 {
+		return nil, errors.New("no local addresses to listen on")
+	}
+
+	bs := make([]*Bound, 0, len(vs))
+	closeAll := func() {
+		for _, b := range bs {
+			b.Close()
+		}
+	}
+	for i, v := range vs {
+		n, a := v.Split()
+		l, err := c.Listen(ctx, n, a)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+
+		b, err := boundWith(l, n)
+		if err != nil {
+			l.Close()
+			closeAll()
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		bs = append(bs, b)
+	}
+
+	return MultiListenerOf(bs...)
+}
+
+// MultiListenerOf returns a listener which accepts connections from the given listeners.
+// It owns the listeners, so they are closed when it is closed.
+// It fails if no listener is given.
+func MultiListenerOf(bs ...*Bound) (*MultiListener, error) {
+	if len(bs) == 0 {
+		return nil, errors.New("no listeners")
+	}
+
+	m := &MultiListener{
+		bounds: bs,
+		conns:  make(chan acceptResult),
+		done:   make(chan struct{}),
+	}
+	m.wg.Add(len(bs))
+	for _, b := range bs {
+		go m.serve(b)
+	}
+	return m, nil
+}
+
+// serve forwards the results of Accept until the listener is closed.
+// Errors are forwarded as they are without retrying,
+// so the caller backs off on temporary errors as [net/http.Server] does.
+// Accept is not called again until the result is taken.
+func (m *MultiListener) serve(l net.Listener) {
+	defer m.wg.Done()
+
+	for {
+		conn, err := l.Accept()
+		select {
+		case m.conns <- acceptResult{conn, err}:
+		case <-m.done:
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+	}
+}
+
+// Accept waits for and returns the next connection from any of the listeners.
+func (m *MultiListener) Accept() (net.Conn, error) {
+	select {
+	case r := <-m.conns:
+		return r.conn, r.err
+	case <-m.done:
+		return nil, &net.OpError{Op: "accept", Net: m.Addr().Network(), Addr: m.Addr(), Err: net.ErrClosed}
+	}
+}
+
+// Close closes all of the listeners.
+func (m *MultiListener) Close() error {
+	m.once.Do(func() {
+		close(m.done)
+
+		errs := []error{}
+		for _, b := range m.bounds {
+			if err := b.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		m.wg.Wait()
+		m.err = errors.Join(errs...)
+	})
+	return m.err
+}
+
+// Addr returns the address of the first listener.
+func (m *MultiListener) Addr() net.Addr {
+	return m.bounds[0].Addr()
+}
+
+// Addrs returns the addresses of all of the listeners.
+func (m *MultiListener) Addrs() []net.Addr {
+	as := make([]net.Addr, len(m.bounds))
+	for i, b := range m.bounds {
+		as[i] = b.Addr()
+	}
+	return as
+}
+
+// Bounds returns the listeners with their bound local addresses.
+func (m *MultiListener) Bounds() []*Bound {
+	return m.bounds
+}
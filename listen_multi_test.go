@@ -0,0 +1,140 @@
+package xddr_test
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestListenAll(t *testing.T) {
+	t.Run("Accept", func(t *testing.T) {
+		s := filepath.Join(t.TempDir(), "test.sock")
+		m, err := xddr.ListenAll([]xddr.Local{
+			"tcp4:127.0.0.1:0",
+			"tcp4:127.0.0.1:0",
+			xddr.Local("unix:" + s),
+		})
+		AssertNoError(t, err)
+		defer m.Close()
+
+		addrs := m.Addrs()
+		AssertEq(t, len(addrs), 3)
+		AssertEq(t, m.Addr(), addrs[0])
+		AssertEq(t, m.Bounds()[2].Local, xddr.Local("unix:"+s))
+
+		for _, addr := range addrs {
+			conn, err := net.Dial(addr.Network(), addr.String())
+			AssertNoError(t, err)
+			defer conn.Close()
+		}
+
+		seen := map[string]bool{}
+		for range addrs {
+			conn, err := m.Accept()
+			AssertNoError(t, err)
+			seen[conn.LocalAddr().String()] = true
+			conn.Close()
+		}
+		AssertEq(t, len(seen), 3)
+	})
+	t.Run("Close", func(t *testing.T) {
+		s := filepath.Join(t.TempDir(), "test.sock")
+		m, err := xddr.ListenAll([]xddr.Local{"tcp4:127.0.0.1:0", xddr.Local("unix:" + s)})
+		AssertNoError(t, err)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := m.Accept()
+			done <- err
+		}()
+
+		addr := m.Addr()
+		AssertNoError(t, m.Close())
+		AssertNoError(t, m.Close())
+		select {
+		case err := <-done:
+			Assert(t, errors.Is(err, net.ErrClosed), "want net.ErrClosed, got %v", err)
+		case <-time.After(time.Second):
+			t.Fatal("accept is not unblocked by close")
+		}
+
+		_, err = net.Dial("tcp", addr.String())
+		Assert(t, err != nil, "want error for closed listener")
+		_, err = os.Stat(s)
+		Assert(t, errors.Is(err, os.ErrNotExist), "want socket file removed, got %v", err)
+	})
+	t.Run("TemporaryError", func(t *testing.T) {
+		l, err := net.Listen("tcp4", "127.0.0.1:0")
+		AssertNoError(t, err)
+		b, err := xddr.BoundOf(&flakyListener{Listener: l, fails: 1})
+		AssertNoError(t, err)
+
+		m, err := xddr.MultiListenerOf(b)
+		AssertNoError(t, err)
+		defer m.Close()
+
+		// The error is forwarded to the caller to back off.
+		_, err = m.Accept()
+		AssertErrorContains(t, err, "temporary failure")
+
+		// The listener is still accepted after the error.
+		conn, err := net.Dial("tcp4", l.Addr().String())
+		AssertNoError(t, err)
+		defer conn.Close()
+
+		done := make(chan error, 1)
+		go func() {
+			conn, err := m.Accept()
+			if err == nil {
+				conn.Close()
+			}
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			AssertNoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("listener stopped accepting after temporary error")
+		}
+	})
+	t.Run("Failure", func(t *testing.T) {
+		s := filepath.Join(t.TempDir(), "test.sock")
+		_, err := xddr.ListenAll([]xddr.Local{xddr.Local("unix:" + s), "tcp4:256.0.0.1:0"})
+		AssertErrorContains(t, err, "[1]: ")
+
+		// Listeners opened are closed.
+		_, err = os.Stat(s)
+		Assert(t, errors.Is(err, os.ErrNotExist), "want socket file removed, got %v", err)
+
+		_, err = xddr.ListenAll(nil)
+		AssertErrorContains(t, err, "no local addresses")
+
+		_, err = xddr.MultiListenerOf()
+		AssertErrorContains(t, err, "no listeners")
+	})
+}
+
+// flakyListener fails to accept with a temporary error for the given number of times.
+type flakyListener struct {
+	net.Listener
+	fails int
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	if l.fails > 0 {
+		l.fails--
+		return nil, temporaryError{}
+	}
+	return l.Listener.Accept()
+}
+
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "temporary failure" }
+func (temporaryError) Timeout() bool   { return false }
+func (temporaryError) Temporary() bool { return true }
@@ -0,0 +1,15 @@
+//go:build windows || plan9
+
+package xddr_test
+
+import "testing"
+
+func otherGID(t *testing.T) int {
+	t.Skip("not supported")
+	return -1
+}
+
+func ownerOf(t *testing.T, p string) (uid int, gid int) {
+	t.Skip("not supported")
+	return -1, -1
+}
@@ -0,0 +1,38 @@
+//go:build !windows && !plan9
+
+package xddr_test
+
+import (
+	"os"
+	"syscall"
+	"testing"
+)
+
+// otherGID returns a group ID which is not the group of the process
+// but the process can change the group of its files to.
+func otherGID(t *testing.T) int {
+	gid := os.Getgid()
+	if os.Geteuid() == 0 {
+		return gid + 1
+	}
+
+	gids, err := os.Getgroups()
+	AssertNoError(t, err)
+	for _, g := range gids {
+		if g != gid {
+			return g
+		}
+	}
+	t.Skip("no supplementary group")
+	return -1
+}
+
+// ownerOf returns the user ID and the group ID of the file.
+func ownerOf(t *testing.T, p string) (uid int, gid int) {
+	fi, err := os.Lstat(p)
+	AssertNoError(t, err)
+
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	Assert(t, ok, "want *syscall.Stat_t, got %T", fi.Sys())
+	return int(st.Uid), int(st.Gid)
+}
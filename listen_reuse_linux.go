@@ -0,0 +1,6 @@
+//go:build linux && !mips && !mipsle && !mips64 && !mips64le
+
+package xddr
+
+// Package syscall does not define SO_REUSEPORT for some of architectures.
+const soReusePort = 0xf
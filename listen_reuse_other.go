@@ -0,0 +1,21 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package xddr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// checkReuse fails if the options are set since they are not supported on this platform.
+// It is checked before listening since [net.ListenConfig.Control] is not called on some platforms, such as Plan 9.
+func checkReuse(addr bool, port bool) error {
+	if !addr && !port {
+		return nil
+	}
+	return fmt.Errorf("SO_REUSEADDR and SO_REUSEPORT: %w", errors.ErrUnsupported)
+}
+
+func setReuse(fd uintptr, addr bool, port bool) error {
+	return checkReuse(addr, port)
+}
@@ -0,0 +1,23 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package xddr
+
+import "syscall"
+
+func checkReuse(addr bool, port bool) error {
+	return nil
+}
+
+func setReuse(fd uintptr, addr bool, port bool) error {
+	if addr {
+		if err := syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1); err != nil {
+			return err
+		}
+	}
+	if port {
+		if err := syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, soReusePort, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
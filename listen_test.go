@@ -0,0 +1,141 @@
+package xddr_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestListenConfig(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ReusePort", func(t *testing.T) {
+		c := &xddr.ListenConfig{ReuseAddr: true, ReusePort: true}
+		l1, err := xddr.ListenWith(ctx, c, xddr.TCPLocal("tcp4:127.0.0.1:0"))
+		if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+			Assert(t, errors.Is(err, errors.ErrUnsupported), "want unsupported, got %v", err)
+			return
+		}
+		AssertNoError(t, err)
+		defer l1.Close()
+
+		l2, err := c.Listen(ctx, "tcp4", l1.Addr().String())
+		AssertNoError(t, err)
+		defer l2.Close()
+
+		_, err = (&xddr.ListenConfig{}).Listen(ctx, "tcp4", l1.Addr().String())
+		AssertErrorContains(t, err, "address already in use")
+	})
+	t.Run("Mode", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "test.sock")
+		c := &xddr.ListenConfig{Mode: 0o600 | os.ModeSocket}
+		l, err := xddr.ListenWith(ctx, c, xddr.UnixLocal("unix:"+p))
+		AssertNoError(t, err)
+		defer l.Close()
+
+		fi, err := os.Stat(p)
+		AssertNoError(t, err)
+		AssertEq(t, fi.Mode().Perm(), 0o600)
+	})
+	t.Run("Owner", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("not supported")
+		}
+
+		gid := otherGID(t)
+		p := filepath.Join(t.TempDir(), "test.sock")
+		c := &xddr.ListenConfig{Owner: &xddr.UnixOwner{UID: -1, GID: gid}}
+		l, err := xddr.ListenWith(ctx, c, xddr.UnixLocal("unix:"+p))
+		AssertNoError(t, err)
+		defer l.Close()
+
+		uid, v := ownerOf(t, p)
+		AssertEq(t, uid, os.Geteuid())
+		AssertEq(t, v, gid)
+	})
+	t.Run("MkdirAll", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "a", "b", "test.sock")
+
+		_, err := xddr.ListenWith(ctx, &xddr.ListenConfig{}, xddr.UnixLocal("unix:"+p))
+		Assert(t, err != nil, "want error for missing directory")
+
+		l, err := xddr.ListenWith(ctx, &xddr.ListenConfig{MkdirAll: true}, xddr.UnixLocal("unix:"+p))
+		AssertNoError(t, err)
+		l.Close()
+	})
+	t.Run("RemoveStale", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "test.sock")
+
+		// Leave the socket file as a crashed process does.
+		l, err := xddr.ListenWith(ctx, &xddr.ListenConfig{KeepOnClose: true}, xddr.UnixLocal("unix:"+p))
+		AssertNoError(t, err)
+		l.Close()
+
+		_, err = xddr.ListenWith(ctx, &xddr.ListenConfig{}, xddr.UnixLocal("unix:"+p))
+		AssertErrorContains(t, err, "address already in use")
+
+		c := &xddr.ListenConfig{RemoveStale: true}
+		l, err = xddr.ListenWith(ctx, c, xddr.UnixLocal("unix:"+p))
+		AssertNoError(t, err)
+		defer l.Close()
+
+		// Socket in use is not removed.
+		_, err = xddr.ListenWith(ctx, c, xddr.UnixLocal("unix:"+p))
+		AssertErrorContains(t, err, "address already in use")
+
+		// Non-socket file is not removed.
+		q := filepath.Join(t.TempDir(), "file")
+		AssertNoError(t, os.WriteFile(q, nil, 0o644))
+		_, err = xddr.ListenWith(ctx, c, xddr.UnixLocal("unix:"+q))
+		AssertErrorContains(t, err, "address already in use")
+		_, err = os.Stat(q)
+		AssertNoError(t, err)
+	})
+	t.Run("KeepOnClose", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "test.sock")
+
+		l, err := xddr.ListenWith(ctx, &xddr.ListenConfig{}, xddr.UnixLocal("unix:"+p))
+		AssertNoError(t, err)
+		l.Close()
+		_, err = os.Stat(p)
+		Assert(t, errors.Is(err, os.ErrNotExist), "want socket file removed, got %v", err)
+
+		l, err = xddr.ListenWith(ctx, &xddr.ListenConfig{KeepOnClose: true}, xddr.UnixLocal("unix:"+p))
+		AssertNoError(t, err)
+		l.Close()
+		_, err = os.Stat(p)
+		AssertNoError(t, err)
+	})
+	t.Run("Abstract", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("not supported")
+		}
+
+		// Options for the socket file are not applied.
+		c := &xddr.ListenConfig{Mode: 0o600, RemoveStale: true, MkdirAll: true}
+		l, err := xddr.ListenWith(ctx, c, xddr.UnixLocal("unix:@xddr-test-listen"))
+		AssertNoError(t, err)
+		defer l.Close()
+		AssertEq(t, l.Addr().String(), "@xddr-test-listen")
+	})
+	t.Run("ListenPacket", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "a", "test.sock")
+		c := &xddr.ListenConfig{MkdirAll: true, Mode: 0o660}
+		conn, err := c.ListenPacket(ctx, "unixgram", p)
+		AssertNoError(t, err)
+		defer conn.Close()
+
+		fi, err := os.Stat(p)
+		AssertNoError(t, err)
+		AssertEq(t, fi.Mode().Perm(), 0o660)
+
+		conn, err = xddr.ListenPacketWith(ctx, c, xddr.UDPLocal("udp4:127.0.0.1:0"))
+		AssertNoError(t, err)
+		conn.Close()
+	})
+}
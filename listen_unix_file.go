@@ -0,0 +1,21 @@
+//go:build !plan9
+
+package xddr
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// isConnRefused reports whether the dial error is that no one accepts connections on the address.
+func isConnRefused(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// keepUnixFile keeps the socket file of the listener on Close.
+func keepUnixFile(l net.Listener) {
+	if ul, ok := l.(*net.UnixListener); ok {
+		ul.SetUnlinkOnClose(false)
+	}
+}
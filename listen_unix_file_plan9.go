@@ -0,0 +1,13 @@
+package xddr
+
+import "net"
+
+// isConnRefused reports whether the dial error is that no one accepts connections on the address.
+// Plan 9 does not have unix sockets, so it never happens.
+func isConnRefused(err error) bool {
+	return false
+}
+
+// keepUnixFile keeps the socket file of the listener on Close.
+// Plan 9 does not have unix sockets, so it does nothing.
+func keepUnixFile(l net.Listener) {}
@@ -1,6 +1,9 @@
 package xddr
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net"
 	"strings"
 )
@@ -19,6 +22,10 @@ import (
 //	tcp:0.0.0.0:443
 //	udp:[::]:53
 //	unix:/var/run/socket.sock
+//	unix:@socket
+//	fd:http
+//	systemd:3
+//	mem:foo
 type Local string
 
 func (v Local) Split() (network, address string) {
@@ -36,6 +43,78 @@ func (v Local) Address() string {
 	return w
 }
 
+// Sanitize sanitizes the address by the type of its network.
+// An address without known network is sanitized as [TCPUnixLocal].
+func (v Local) Sanitize() (Local, error) {
+	switch v.Network() {
+	case "tcp", "tcp4", "tcp6":
+		return transWithErr[Local](TCPLocal(v).Sanitize())
+	case "udp", "udp4", "udp6":
+		return transWithErr[Local](UDPLocal(v).Sanitize())
+	case "unix", "unixgram", "unixpacket", "unix-abstract":
+		return transWithErr[Local](UnixLocal(v).Sanitize())
+	case "fd", "systemd":
+		return transWithErr[Local](FDLocal(v).Sanitize())
+	case "vsock":
+		return transWithErr[Local](VsockLocal(v).Sanitize())
+	case "mem":
+		return transWithErr[Local](MemLocal(v).Sanitize())
+	default:
+		return transWithErr[Local](TCPUnixLocal(v).Sanitize())
+	}
+}
+
+// Locals is a list of local addresses which implements [flag.Value].
+// Addresses are separated by commas, and the flag can be given multiple times.
+//
+// Examples:
+//
+//	tcp4:0.0.0.0:80,tcp6:[::]:80
+//	unix:/run/app.sock
+type Locals []Local
+
+func (v Locals) Sanitize() (Locals, error) {
+	rs := make(Locals, len(v))
+	for i, l := range v {
+		w, err := l.Sanitize()
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		rs[i] = w
+	}
+	return rs, nil
+}
+
+func (v Locals) String() string {
+	ss := make([]string, len(v))
+	for i, l := range v {
+		ss[i] = string(l)
+	}
+	return strings.Join(ss, ",")
+}
+
+// Set appends the comma-separated addresses after sanitizing them.
+func (v *Locals) Set(s string) error {
+	var rs Locals
+	for _, w := range strings.Split(s, ",") {
+		w = strings.TrimSpace(w)
+		if w == "" {
+			continue
+		}
+		l, err := Local(w).Sanitize()
+		if err != nil {
+			return fmt.Errorf("%s: %w", w, err)
+		}
+		rs = append(rs, l)
+	}
+	if len(rs) == 0 {
+		return errors.New("empty local address")
+	}
+
+	*v = append(*v, rs...)
+	return nil
+}
+
 type LocalLike interface {
 	~string
 	_localLike()
@@ -58,6 +137,15 @@ func isStream(net string) bool {
 	}
 }
 
+func isIPNetwork(net string) bool {
+	switch net {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+		return true
+	default:
+		return false
+	}
+}
+
 func isDgram(net string) bool {
 	switch net {
 	case "udp", "udp4", "udp6", "unixgram":
@@ -67,25 +155,99 @@ func isDgram(net string) bool {
 	}
 }
 
-func Listen[T LocalLike](v T) (net.Listener, error) {
-	n, a := Local(v).Split()
-	return net.Listen(n, a)
+func Listen[T LocalLike](v T) (*Bound, error) {
+	return ListenWith(context.Background(), &ListenConfig{}, v)
 }
 
 func ListenPacket[T LocalLike](v T) (net.PacketConn, error) {
-	n, a := Local(v).Split()
-	return net.ListenPacket(n, a)
+	return ListenPacketWith(context.Background(), &ListenConfig{}, v)
+}
+
+// DialLocal connects to the local address using the zero [Dialer] as [Dial] does.
+// An empty or unspecified host is dialed to the loopback,
+// so the address given to [Listen] can be dialed as it is.
+func DialLocal[T LocalLike](v T) (net.Conn, error) {
+	return DialWith(context.Background(), &Dialer{}, Local(v))
+}
+
+// DialPacket connects to the local address of datagram network using the zero [Dialer].
+// The connection is connected to the address, so packets are sent by Write
+// and only packets from the address are received by Read.
+// Use [ListenPacket] for a connection which sends packets to any address by WriteTo.
+func DialPacket[T LocalLike](v T) (net.Conn, error) {
+	if !isDgram(NetworkOf(v)) {
+		return nil, fmt.Errorf("not a datagram network: %q", NetworkOf(v))
+	}
+	return DialLocal(v)
 }
 
+// UnixLocal represents a local address of unix domain socket.
+// An address starting with "@" is in the abstract namespace of Linux,
+// which is also given by "unix-abstract" network as gRPC does.
+//
+// Examples:
+//
+//	unix:/var/run/socket.sock
+//	unix:@socket
+//	unix-abstract:socket
 type UnixLocal string
 
+func (v UnixLocal) _localLike() {}
+
 func (v UnixLocal) Sanitize() (UnixLocal, error) {
-	net, addr := Local(v).Split()
-	if net == "" {
-		net = "unix"
+	s := string(v)
+	if s == "" {
+		return "", errors.New("empty local address")
 	}
 
-	// Validate filepath?
+	net, addr := "unix", s
+	switch s[0] {
+	case '.', '/', '@':
+		// Path without network.
+	default:
+		net, addr = Local(v).Split()
+		switch net {
+		case "":
+			net = "unix"
+		case "unix-abstract":
+			net = "unix"
+			addr = "@" + addr
+		}
+	}
+	if err := checkUnixAddress(addr); err != nil {
+		return "", err
+	}
 
 	return UnixLocal(net + ":" + addr), nil
 }
+
+// netAddrOf maps the network and the address given in the forms which are not known to net package.
+func netAddrOf(network, address string) (string, string) {
+	if network == "unix-abstract" {
+		return "unix", "@" + address
+	}
+	return network, address
+}
+
+// maxUnixAddress is the size of sun_path in sockaddr_un excluding the terminating null byte,
+// or the leading null byte for the abstract namespace.
+const maxUnixAddress = 108 - 1
+
+func checkUnixAddress(addr string) error {
+	if name, ok := strings.CutPrefix(addr, "@"); ok {
+		if name == "" {
+			return errors.New("missing abstract socket name")
+		}
+		if len(name) > maxUnixAddress {
+			return fmt.Errorf("abstract socket name too long: %d bytes exceeds %d", len(name), maxUnixAddress)
+		}
+		return nil
+	}
+	if addr == "" {
+		return errors.New("missing socket path")
+	}
+	if len(addr) > maxUnixAddress {
+		return fmt.Errorf("socket path too long: %d bytes exceeds %d", len(addr), maxUnixAddress)
+	}
+	return nil
+}
@@ -1,7 +1,10 @@
 package xddr_test
 
 import (
+	"flag"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/lesomnus/xddr"
@@ -32,6 +35,8 @@ func TestTCPLocal(t *testing.T) {
 			{"tcp::80", "tcp::80"},
 			{"tcp4::80", "tcp4:0.0.0.0:80"},
 			{"tcp6::80", "tcp6:[::]:80"},
+			{"[fe80::1%eth0]:80", "tcp6:[fe80::1%eth0]:80"},
+			{"tcp:[fe80::1%25eth0]:80", "tcp6:[fe80::1%eth0]:80"},
 		} {
 			t.Run(fmt.Sprintf("Local(%q).Sanitize()=%q", given[0], given[1]), func(t *testing.T) {
 				v, err := xddr.TCPLocal(given[0]).Sanitize()
@@ -41,3 +46,94 @@ func TestTCPLocal(t *testing.T) {
 		}
 	})
 }
+
+func TestUnixLocal(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, given := range [][]string{
+			{"/var/run/test.sock", "unix:/var/run/test.sock"},
+			{"./test.sock", "unix:./test.sock"},
+			{":/var/run/test.sock", "unix:/var/run/test.sock"},
+			{"unix:/var/run/test.sock", "unix:/var/run/test.sock"},
+			{"unixgram:/var/run/test.sock", "unixgram:/var/run/test.sock"},
+			{"@test", "unix:@test"},
+			{"unix:@test", "unix:@test"},
+			{"unix-abstract:test", "unix:@test"},
+			{"unix:/" + strings.Repeat("a", 106), "unix:/" + strings.Repeat("a", 106)},
+			{"unix-abstract:" + strings.Repeat("a", 107), "unix:@" + strings.Repeat("a", 107)},
+		} {
+			t.Run(fmt.Sprintf("UnixLocal(%q).Sanitize()=%q", given[0], given[1]), func(t *testing.T) {
+				v, err := xddr.UnixLocal(given[0]).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, xddr.UnixLocal(given[1]))
+			})
+		}
+		for _, given := range [][]string{
+			{"/var/run/test.sock", "unix:/var/run/test.sock"},
+			{"unix:/var/run/test.sock", "unix:/var/run/test.sock"},
+			{"@test", "unix:@test"},
+			{"unix-abstract:test", "unix:@test"},
+		} {
+			t.Run(fmt.Sprintf("TCPUnixLocal(%q).Sanitize()=%q", given[0], given[1]), func(t *testing.T) {
+				v, err := xddr.TCPUnixLocal(given[0]).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, xddr.TCPUnixLocal(given[1]))
+			})
+		}
+		for _, tc := range [][]string{
+			{"empty local address", ""},
+			{"missing socket path", "unix:"},
+			{"missing abstract socket name", "unix:@", "unix-abstract:"},
+			{"socket path too long", "unix:/" + strings.Repeat("a", 107)},
+			{"abstract socket name too long", "@" + strings.Repeat("a", 108), "unix-abstract:" + strings.Repeat("a", 108)},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("UnixLocal(%q).Sanitize() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.UnixLocal(given).Sanitize()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+}
+
+func TestLocalSanitize(t *testing.T) {
+	for _, given := range [][]string{
+		{":80", "tcp::80"},
+		{"0.0.0.0:80", "tcp4:0.0.0.0:80"},
+		{"tcp6::80", "tcp6:[::]:80"},
+		{"udp::53", "udp::53"},
+		{"udp4::53", "udp4:0.0.0.0:53"},
+		{"/run/app.sock", "unix:/run/app.sock"},
+		{"unixgram:/run/app.sock", "unixgram:/run/app.sock"},
+		{"unix-abstract:app", "unix:@app"},
+		{"fd:003", "fd:3"},
+		{"vsock:03:1024", "vsock:3:1024"},
+	} {
+		t.Run(fmt.Sprintf("Local(%q).Sanitize()=%q", given[0], given[1]), func(t *testing.T) {
+			v, err := xddr.Local(given[0]).Sanitize()
+			AssertNoError(t, err)
+			AssertEq(t, v, xddr.Local(given[1]))
+		})
+	}
+}
+
+func TestLocals(t *testing.T) {
+	var v xddr.Locals
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&v, "listen", "addresses to listen on")
+
+	err := fs.Parse([]string{"--listen=0.0.0.0:80, tcp6::80", "--listen", "unix:/run/app.sock"})
+	AssertNoError(t, err)
+	AssertEq(t, len(v), 3)
+	AssertEq(t, v.String(), "tcp4:0.0.0.0:80,tcp6:[::]:80,unix:/run/app.sock")
+
+	err = fs.Parse([]string{"--listen=:80,tcp4:[::]:80"})
+	AssertErrorContains(t, err, "tcp4:[::]:80: invalid local address")
+
+	err = fs.Parse([]string{"--listen=,"})
+	AssertErrorContains(t, err, "empty local address")
+
+	_, err = xddr.Locals{":80", "unix:"}.Sanitize()
+	AssertErrorContains(t, err, "[1]: missing socket path")
+}
@@ -0,0 +1,193 @@
+package xddr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrAddressInUse is returned when listening on a [MemLocal] address which is already listened on.
+	ErrAddressInUse = errors.New("address already in use")
+
+	// ErrConnectionRefused is returned when dialing a [MemLocal] address which no one listens on.
+	ErrConnectionRefused = errors.New("connection refused")
+)
+
+// MemLocal represents an in-process address which does not use any socket.
+// Listening on it returns a listener registered in the process by the name,
+// and dialing it connects to the listener by synchronous in-memory connections as [net.Pipe] does.
+// Dialing completes before the connection is accepted unless the backlog of the listener is full,
+// as a socket does.
+// It is intended for tests to use the same typed addresses as production.
+//
+// Syntax:
+//
+//	mem:<name>
+//
+// Examples:
+//
+//	mem:foo
+//	mem:grpc-server
+type MemLocal string
+
+func (v MemLocal) _localLike() {}
+
+func (v MemLocal) Sanitize() (MemLocal, error) {
+	s := string(v)
+	if s == "" {
+		return "", errors.New("empty local address")
+	}
+
+	net, name, ok := strings.Cut(s, ":")
+	if !ok || net != "mem" {
+		return "", errors.New(`network must be "mem"`)
+	}
+	if name == "" {
+		return "", errPosF(len(net)+1, "missing name")
+	}
+	for i, c := range []byte(name) {
+		if c <= ' ' || c == 0x7f {
+			return "", errPosF(len(net)+1+i, "invalid character in name")
+		}
+	}
+	return MemLocal(s), nil
+}
+
+// memAddr is an address of in-process listener.
+type memAddr string
+
+func (a memAddr) Network() string {
+	return "mem"
+}
+
+func (a memAddr) String() string {
+	return string(a)
+}
+
+var memListeners = struct {
+	sync.Mutex
+	m map[string]*memListener
+}{m: map[string]*memListener{}}
+
+// memBacklog is the number of connections which can be dialed before they are accepted.
+const memBacklog = 16
+
+type memListener struct {
+	addr  memAddr
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+
+	// mu guards closed, so no connection is queued after Close drains the backlog.
+	mu      sync.Mutex
+	closed  bool
+	sending sync.WaitGroup
+}
+
+func listenMem(name string) (net.Listener, error) {
+	if name == "" {
+		return nil, &net.OpError{Op: "listen", Net: "mem", Err: errors.New("missing name")}
+	}
+
+	memListeners.Lock()
+	defer memListeners.Unlock()
+	if _, ok := memListeners.m[name]; ok {
+		return nil, &net.OpError{Op: "listen", Net: "mem", Addr: memAddr(name), Err: ErrAddressInUse}
+	}
+
+	l := &memListener{
+		addr:  memAddr(name),
+		conns: make(chan net.Conn, memBacklog),
+		done:  make(chan struct{}),
+	}
+	memListeners.m[name] = l
+	return l, nil
+}
+
+func (l *memListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, &net.OpError{Op: "accept", Net: "mem", Addr: l.addr, Err: net.ErrClosed}
+	}
+}
+
+func (l *memListener) Close() error {
+	l.once.Do(func() {
+		memListeners.Lock()
+		delete(memListeners.m, string(l.addr))
+		memListeners.Unlock()
+
+		l.mu.Lock()
+		l.closed = true
+		l.mu.Unlock()
+		close(l.done)
+
+		// Close the connections dialed but not accepted.
+		l.sending.Wait()
+		for {
+			select {
+			case conn := <-l.conns:
+				conn.Close()
+			default:
+				return
+			}
+		}
+	})
+	return nil
+}
+
+func (l *memListener) Addr() net.Addr {
+	return l.addr
+}
+
+func dialMem(ctx context.Context, name string) (net.Conn, error) {
+	memListeners.Lock()
+	l, ok := memListeners.m[name]
+	memListeners.Unlock()
+	if !ok {
+		return nil, &net.OpError{Op: "dial", Net: "mem", Addr: memAddr(name), Err: ErrConnectionRefused}
+	}
+
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil, &net.OpError{Op: "dial", Net: "mem", Addr: l.addr, Err: ErrConnectionRefused}
+	}
+	l.sending.Add(1)
+	l.mu.Unlock()
+	defer l.sending.Done()
+
+	c, s := net.Pipe()
+	select {
+	case l.conns <- &memConn{Conn: s, local: l.addr, remote: ""}:
+		return &memConn{Conn: c, local: "", remote: l.addr}, nil
+	case <-l.done:
+		c.Close()
+		s.Close()
+		return nil, &net.OpError{Op: "dial", Net: "mem", Addr: l.addr, Err: ErrConnectionRefused}
+	case <-ctx.Done():
+		c.Close()
+		s.Close()
+		return nil, &net.OpError{Op: "dial", Net: "mem", Addr: l.addr, Err: ctx.Err()}
+	}
+}
+
+// memConn is a connection of [net.Pipe] with in-process addresses.
+type memConn struct {
+	net.Conn
+	local  memAddr
+	remote memAddr
+}
+
+func (c *memConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *memConn) RemoteAddr() net.Addr {
+	return c.remote
+}
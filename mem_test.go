@@ -0,0 +1,142 @@
+package xddr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestMemLocal(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, given := range []string{
+			"mem:foo",
+			"mem:grpc-server",
+		} {
+			t.Run(fmt.Sprintf("MemLocal(%q).Sanitize()", given), func(t *testing.T) {
+				v, err := xddr.MemLocal(given).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, xddr.MemLocal(given))
+
+				w, err := xddr.TCPUnixLocal(given).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, w, xddr.TCPUnixLocal(given))
+
+				l, err := xddr.Local(given).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, l, xddr.Local(given))
+			})
+		}
+		for _, tc := range [][]string{
+			{"empty local address", ""},
+			{`network must be "mem"`, "tcp:foo"},
+			{"[4]: missing name", "mem:"},
+			{"[7]: invalid character", "mem:foo bar"},
+		} {
+			t.Run(fmt.Sprintf("MemLocal(%q).Sanitize() -> %q", tc[1], tc[0]), func(t *testing.T) {
+				_, err := xddr.MemLocal(tc[1]).Sanitize()
+				AssertErrorContains(t, err, tc[0])
+			})
+		}
+	})
+	t.Run("AsURL", func(t *testing.T) {
+		AssertEq(t, xddr.GRPCLocal("mem:foo").AsURL(), "mem:foo")
+		AssertEq(t, xddr.GRPC("mem:foo").Local(), "mem:foo")
+		AssertEq(t, xddr.HTTPLocal("mem:foo").AsURL(), "")
+	})
+
+	ctx := context.Background()
+	t.Run("Listen", func(t *testing.T) {
+		b, err := xddr.Listen(xddr.GRPCLocal("mem:listen"))
+		AssertNoError(t, err)
+		defer b.Close()
+		AssertEq(t, b.Local, "mem:listen")
+		AssertEq(t, b.GRPC(), "mem:listen")
+		AssertEq(t, b.HTTP(), "")
+
+		_, err = xddr.Listen(xddr.MemLocal("mem:listen"))
+		Assert(t, errors.Is(err, xddr.ErrAddressInUse), "want ErrAddressInUse, got %v", err)
+
+		go func() {
+			conn, err := b.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			conn.Write([]byte("hello"))
+		}()
+
+		conn, err := xddr.Dial(ctx, b.GRPC())
+		AssertNoError(t, err)
+		defer conn.Close()
+		AssertEq(t, conn.RemoteAddr().Network(), "mem")
+		AssertEq(t, conn.RemoteAddr().String(), "listen")
+
+		v, err := io.ReadAll(conn)
+		AssertNoError(t, err)
+		AssertEq(t, string(v), "hello")
+	})
+	t.Run("Dial", func(t *testing.T) {
+		_, err := xddr.Dial(ctx, xddr.MemLocal("mem:nobody"))
+		Assert(t, errors.Is(err, xddr.ErrConnectionRefused), "want ErrConnectionRefused, got %v", err)
+
+		l, err := xddr.Listen(xddr.MemLocal("mem:dial"))
+		AssertNoError(t, err)
+		defer l.Close()
+
+		// Dialing completes before the connection is accepted.
+		conn, err := xddr.Dial(ctx, xddr.MemLocal("mem:dial"))
+		AssertNoError(t, err)
+		defer conn.Close()
+
+		accepted, err := l.Accept()
+		AssertNoError(t, err)
+		accepted.Close()
+
+		// Nobody accepts until the backlog is full.
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		n := 0
+		for ; n < 1000; n++ {
+			conn, err := xddr.Dial(ctx, xddr.Local("mem:dial"))
+			if err != nil {
+				Assert(t, errors.Is(err, context.DeadlineExceeded), "want deadline exceeded, got %v", err)
+				break
+			}
+			defer conn.Close()
+		}
+		Assert(t, 0 < n && n < 1000, "want dials to block on full backlog, got %d dials", n)
+	})
+	t.Run("Close", func(t *testing.T) {
+		l, err := xddr.Listen(xddr.MemLocal("mem:close"))
+		AssertNoError(t, err)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := l.Accept()
+			done <- err
+		}()
+		AssertNoError(t, l.Close())
+		Assert(t, errors.Is(<-done, net.ErrClosed), "want net.ErrClosed")
+
+		// Connections not accepted are closed.
+		l, err = xddr.Listen(xddr.MemLocal("mem:close"))
+		AssertNoError(t, err)
+		conn, err := xddr.Dial(context.Background(), xddr.MemLocal("mem:close"))
+		AssertNoError(t, err)
+		defer conn.Close()
+		AssertNoError(t, l.Close())
+		_, err = conn.Read(make([]byte, 1))
+		Assert(t, errors.Is(err, io.EOF), "want EOF, got %v", err)
+
+		// The name can be used again.
+		l, err = xddr.Listen(xddr.MemLocal("mem:close"))
+		AssertNoError(t, err)
+		l.Close()
+	})
+}
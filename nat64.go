@@ -0,0 +1,165 @@
+package xddr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NAT64WellKnownPrefix is the Well-Known Prefix for IPv4-embedded IPv6 addresses defined in RFC 6052 §2.1.
+const NAT64WellKnownPrefix IPwithCIDR = "64:ff9b::/96"
+
+// IPv4CompatiblePrefix is the prefix of deprecated IPv4-compatible IPv6 addresses defined in RFC 4291 §2.5.5.1.
+const IPv4CompatiblePrefix IPwithCIDR = "::/96"
+
+// nat64Prefix validates the prefix for IPv4-embedded IPv6 addresses as described in RFC 6052 §2.2
+// and returns its bytes and length.
+func nat64Prefix(prefix IPwithCIDR) ([16]byte, int, error) {
+	ip, n := prefix.Split()
+	ipv6, ok := ip.V6()
+	if !ok || ip == "" {
+		return [16]byte{}, 0, errors.New("prefix must be an IPv6 network")
+	}
+	switch n {
+	case 32, 40, 48, 56, 64, 96:
+	default:
+		return [16]byte{}, 0, fmt.Errorf("prefix length must be one of 32, 40, 48, 56, 64 or 96, got %d", n)
+	}
+
+	b := ipv6.Bytes()
+	if n == 96 && b[8] != 0 {
+		return [16]byte{}, 0, errors.New("bits 64 to 71 of the prefix must be zero")
+	}
+	return b, n, nil
+}
+
+// isNAT64WellKnownPrefix reports whether the prefix of the bytes and the length is [NAT64WellKnownPrefix]
+// regardless of how the prefix is written.
+func isNAT64WellKnownPrefix(b [16]byte, n int) bool {
+	return n == 96 && hasPrefix(b[:], []byte{0x00, 0x64, 0xff, 0x9b}, 96)
+}
+
+// nat64Index returns the byte index of ith octet of the embedded IPv4 address.
+// The octet for bits 64 to 71 ("u" octet) is skipped.
+func nat64Index(n int, i int) int {
+	j := n/8 + i
+	if n <= 64 && j >= 8 {
+		j++
+	}
+	return j
+}
+
+// Map returns the IPv4-mapped IPv6 address.
+func (v IPv4) Map() IPv6 {
+	return IPv6("::ffff:" + v)
+}
+
+// EmbedIn synthesizes an IPv4-embedded IPv6 address with the given prefix as described in RFC 6052 §2.2.
+// The Well-Known Prefix cannot be used for non-global IPv4 addresses such as private ones.
+//
+// Examples:
+//
+//	192.0.2.33 in 2001:db8::/32   -> 2001:db8:c000:221::
+//	192.0.2.33 in 2001:db8::/64   -> 2001:db8::c0:2:2100:0
+//	192.0.2.33 in 64:ff9b::/96    -> 64:ff9b::c000:221
+func (v IPv4) EmbedIn(prefix IPwithCIDR) (IPv6, error) {
+	b, n, err := nat64Prefix(prefix)
+	if err != nil {
+		return "", err
+	}
+	if isNAT64WellKnownPrefix(b, n) {
+		switch {
+		case v.IsPrivate(), v.IsShared(), v.IsLoopback(), v.IsLinkLocal(), v.IsThisNetwork():
+			return "", fmt.Errorf("well-known prefix cannot be used for non-global IPv4 address %s", v)
+		}
+	}
+
+	// Clear bits after the prefix.
+	for i := n / 8; i < 16; i++ {
+		b[i] = 0
+	}
+
+	b4 := v.Bytes()
+	for i, c := range b4 {
+		b[nat64Index(n, i)] = c
+	}
+	return ipv6FromBytes(b), nil
+}
+
+// ExtractIPv4 extracts the IPv4 address embedded with the given prefix as described in RFC 6052 §2.3.
+func (v IPv6) ExtractIPv4(prefix IPwithCIDR) (IPv4, error) {
+	p, n, err := nat64Prefix(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	b := v.Bytes()
+	if !hasPrefix(b[:], p[:], n) {
+		return "", fmt.Errorf("address is not in the prefix %s", prefix)
+	}
+	if n <= 64 && b[8] != 0 {
+		return "", errors.New("bits 64 to 71 of the address must be zero")
+	}
+
+	var b4 [4]byte
+	for i := range b4 {
+		b4[i] = b[nat64Index(n, i)]
+	}
+	return ipv4FromBytes(b4), nil
+}
+
+// IsIPv4Mapped reports whether the address is an IPv4-mapped IPv6 address (::ffff:0:0/96).
+func (v IPv6) IsIPv4Mapped() bool {
+	return v.Class() == IPClassIPv4Mapped
+}
+
+// IsIPv4Compatible reports whether the address is a deprecated IPv4-compatible IPv6 address (::/96),
+// excluding the unspecified and the loopback addresses.
+func (v IPv6) IsIPv4Compatible() bool {
+	b := v.Bytes()
+	if !hasPrefix(b[:], nil, 96) {
+		return false
+	}
+	return b != [16]byte{} && b != [16]byte{15: 1}
+}
+
+// IsNAT64 reports whether the address is in the Well-Known Prefix (64:ff9b::/96).
+func (v IPv6) IsNAT64() bool {
+	return v.Class() == IPClassNAT64
+}
+
+// Extract6to4 returns the IPv4 address embedded in the 6to4 address (2002::/16) as described in RFC 3056 §2.
+func (v IPv6) Extract6to4() (IPv4, bool) {
+	if !v.Is6to4() {
+		return "", false
+	}
+
+	b := v.Bytes()
+	return ipv4FromBytes([4]byte(b[2:6])), true
+}
+
+// ExtractTeredo returns the IPv4 addresses of the server and the client embedded in the Teredo address (2001::/32)
+// as described in RFC 4380 §4, where the client address is stored with all the bits inverted.
+func (v IPv6) ExtractTeredo() (server IPv4, client IPv4, ok bool) {
+	if !v.IsTeredo() {
+		return "", "", false
+	}
+
+	b := v.Bytes()
+	c := [4]byte(b[12:])
+	for i := range c {
+		c[i] ^= 0xff
+	}
+	return ipv4FromBytes([4]byte(b[4:8])), ipv4FromBytes(c), true
+}
+
+// Unmap returns the IPv4 address if the address is an IPv4-mapped IPv6 address.
+// Otherwise, it returns the address as is.
+func (v IP) Unmap() IP {
+	ipv6, ok := v.V6()
+	if !ok || v == "" || !ipv6.IsIPv4Mapped() {
+		return v
+	}
+
+	b := ipv6.Bytes()
+	return IP(ipv4FromBytes([4]byte(b[12:])))
+}
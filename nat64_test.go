@@ -0,0 +1,139 @@
+package xddr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestNAT64(t *testing.T) {
+	t.Run("EmbedIn", func(t *testing.T) {
+		// See RFC 6052 §2.4.
+		for _, tc := range []struct {
+			prefix xddr.IPwithCIDR
+			want   xddr.IPv6
+		}{
+			{"2001:db8::/32", "2001:db8:c000:221::"},
+			{"2001:db8:100::/40", "2001:db8:1c0:2:21::"},
+			{"2001:db8:122::/48", "2001:db8:122:c000:2:2100::"},
+			{"2001:db8:122:300::/56", "2001:db8:122:3c0:0:221::"},
+			{"2001:db8:122:344::/64", "2001:db8:122:344:c0:2:2100:0"},
+			{"2001:db8:122:344::/96", "2001:db8:122:344::c000:221"},
+			{xddr.NAT64WellKnownPrefix, "64:ff9b::c000:221"},
+			{xddr.IPv4CompatiblePrefix, "::c000:221"},
+		} {
+			t.Run(fmt.Sprintf("IPv4(%q).EmbedIn(%q)=%q", "192.0.2.33", tc.prefix, tc.want), func(t *testing.T) {
+				v, err := xddr.IPv4("192.0.2.33").EmbedIn(tc.prefix)
+				AssertNoError(t, err)
+				AssertEq(t, v, tc.want)
+
+				ipv4, err := v.ExtractIPv4(tc.prefix)
+				AssertNoError(t, err)
+				AssertEq(t, ipv4, "192.0.2.33")
+			})
+		}
+		for _, tc := range []struct {
+			ipv4   xddr.IPv4
+			prefix xddr.IPwithCIDR
+			err    string
+		}{
+			{"192.0.2.33", "192.0.2.0/24", "prefix must be an IPv6 network"},
+			{"192.0.2.33", "2001:db8::/33", "prefix length must be one of"},
+			{"192.0.2.33", "2001:db8:0:0:100::/96", "bits 64 to 71 of the prefix must be zero"},
+			{"10.0.0.1", xddr.NAT64WellKnownPrefix, "non-global IPv4 address"},
+			{"192.168.0.1", xddr.NAT64WellKnownPrefix, "non-global IPv4 address"},
+			{"127.0.0.1", "0064:ff9b::/96", "non-global IPv4 address"},
+			{"127.0.0.1", "64:ff9b:0::/96", "non-global IPv4 address"},
+			{"127.0.0.1", "64:FF9B:0:0:0:0::/96", "non-global IPv4 address"},
+		} {
+			t.Run(fmt.Sprintf("IPv4(%q).EmbedIn(%q) -> %q", tc.ipv4, tc.prefix, tc.err), func(t *testing.T) {
+				_, err := tc.ipv4.EmbedIn(tc.prefix)
+				AssertErrorContains(t, err, tc.err)
+			})
+		}
+	})
+	t.Run("ExtractIPv4", func(t *testing.T) {
+		for _, tc := range []struct {
+			ipv6   xddr.IPv6
+			prefix xddr.IPwithCIDR
+			err    string
+		}{
+			{"2001:db9::1", "2001:db8::/32", "not in the prefix"},
+			{"2001:db8:c000:221:100::", "2001:db8::/32", "bits 64 to 71 of the address must be zero"},
+		} {
+			t.Run(fmt.Sprintf("IPv6(%q).ExtractIPv4(%q) -> %q", tc.ipv6, tc.prefix, tc.err), func(t *testing.T) {
+				_, err := tc.ipv6.ExtractIPv4(tc.prefix)
+				AssertErrorContains(t, err, tc.err)
+			})
+		}
+	})
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.IPv6
+			want  xddr.IPv6
+		}{
+			{"64:ff9b::192.0.2.33", "64:ff9b::c000:221"},
+			{"2001:db8:122:344::192.0.2.33", "2001:db8:122:344::c000:221"},
+			{"::192.0.2.33", "::c000:221"},
+			{"1:2:3:4:5:6:192.0.2.33", "1:2:3:4:5:6:c000:221"},
+			{"::ffff:c000:221", "::ffff:192.0.2.33"},
+		} {
+			t.Run(fmt.Sprintf("IPv6(%q).Sanitize()=%q", tc.given, tc.want), func(t *testing.T) {
+				v, err := tc.given.Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, tc.want)
+			})
+		}
+		for _, tc := range [][]string{
+			{"invalid IPv4-embedded IPv6 address",
+				"64:ff9b::192.0.2",
+				"::1.2.3.256",
+			},
+			{"must have at most 8 blocks",
+				"1:2:3:4:5:6:7:192.0.2.33",
+			},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("IPv6(%q).Sanitize() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.IPv6(given).Sanitize()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+	t.Run("Extract6to4", func(t *testing.T) {
+		v, ok := xddr.IPv6("2002:c000:221::1").Extract6to4()
+		Assert(t, ok, "want 6to4 address")
+		AssertEq(t, v, "192.0.2.33")
+
+		_, ok = xddr.IPv6("2001:db8::1").Extract6to4()
+		Assert(t, !ok, "want not 6to4 address")
+	})
+	t.Run("ExtractTeredo", func(t *testing.T) {
+		// Example of RFC 4380 §4.
+		server, client, ok := xddr.IPv6("2001:0:4136:e378:8000:63bf:3fff:fdd2").ExtractTeredo()
+		Assert(t, ok, "want Teredo address")
+		AssertEq(t, server, "65.54.227.120")
+		AssertEq(t, client, "192.0.2.45")
+
+		_, _, ok = xddr.IPv6("2002:c000:221::1").ExtractTeredo()
+		Assert(t, !ok, "want not Teredo address")
+	})
+	t.Run("MapUnmap", func(t *testing.T) {
+		AssertEq(t, xddr.IPv4("192.0.2.33").Map(), "::ffff:192.0.2.33")
+		AssertEq(t, xddr.IP("::ffff:192.0.2.33").Unmap(), "192.0.2.33")
+		AssertEq(t, xddr.IP("::192.0.2.33").Unmap(), "::192.0.2.33")
+		AssertEq(t, xddr.IP("192.0.2.33").Unmap(), "192.0.2.33")
+		AssertEq(t, xddr.IP("::1").Unmap(), "::1")
+
+		Assert(t, xddr.IPv6("::ffff:192.0.2.33").IsIPv4Mapped(), "want IPv4-mapped")
+		Assert(t, xddr.IPv6("::c000:221").IsIPv4Compatible(), "want IPv4-compatible")
+		Assert(t, !xddr.IPv6("::1").IsIPv4Compatible(), "want not IPv4-compatible")
+		Assert(t, xddr.IPv6("64:ff9b::c000:221").IsNAT64(), "want NAT64")
+
+		v, err := xddr.IPwithCIDR("64:ff9b::192.0.2.0/120").Sanitize()
+		AssertNoError(t, err)
+		AssertEq(t, v, "64:ff9b::c000:200/120")
+	})
+}
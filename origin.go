@@ -0,0 +1,252 @@
+package xddr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Origin represents a web origin, a tuple of scheme, host, and port (RFC 6454).
+// It is serialized as in the Origin header of HTTP where the default port of the scheme is omitted.
+// An opaque origin is serialized as "null".
+//
+// Syntax:
+//
+//	<scheme>://<host>[:<port>] | null
+//
+// Examples:
+//
+//	https://example.com
+//	http://example.com:8080
+//	https://[::1]
+//	null
+type Origin string
+
+// OriginNull is the serialization of an opaque origin.
+const OriginNull Origin = "null"
+
+// defaultPortOf returns the default port of the scheme, or -1 if unknown.
+func defaultPortOf(scheme string) int {
+	switch scheme {
+	case "http", "ws":
+		return 80
+	case "https", "wss":
+		return 443
+	}
+	return -1
+}
+
+func originOf(scheme string, host Host, port int) Origin {
+	if port < 0 || port == defaultPortOf(scheme) {
+		return Origin(scheme + "://" + string(host))
+	}
+	return Origin(scheme + "://" + string(host) + ":" + strconv.Itoa(port))
+}
+
+// Origin returns the origin of the URL.
+func (v HTTP) Origin() Origin {
+	s, _, a, _, _, _ := URL(v).split()
+	return originOf(strings.ToLower(s), a.Host(), a.Port())
+}
+
+func (v Origin) Sanitize() (Origin, error) {
+	s := string(v)
+	if s == "" {
+		return "", errors.New("origin cannot be empty")
+	}
+	if s == string(OriginNull) {
+		return OriginNull, nil
+	}
+
+	u, err := URL(s).Sanitize()
+	if err != nil {
+		return "", err
+	}
+
+	scheme, h, a, p, q, f := u.split()
+	switch {
+	case !h:
+		return "", errors.New("origin must have an authority")
+	case p != "":
+		return "", errors.New("origin cannot have a path")
+	case q != "" || strings.Contains(s, "?"):
+		return "", errors.New("origin cannot have a query")
+	case f != "" || strings.Contains(s, "#"):
+		return "", errors.New("origin cannot have a fragment")
+	case a.Userinfo() != "" || strings.Contains(s, "@"):
+		return "", errors.New("origin cannot have userinfo")
+	}
+
+	host := a.Host()
+	if host == "" {
+		return "", errors.New("missing host")
+	}
+
+	return originOf(scheme, host, a.Port()), nil
+}
+
+// IsOpaque reports whether the origin is an opaque origin.
+func (v Origin) IsOpaque() bool {
+	return v == OriginNull
+}
+
+func (v Origin) Scheme() string {
+	if v.IsOpaque() {
+		return ""
+	}
+	return URL(v).Scheme()
+}
+
+func (v Origin) Host() Host {
+	if v.IsOpaque() {
+		return ""
+	}
+	return URL(v).Host()
+}
+
+// Port returns the effective port of the origin, which is the default port of the scheme if omitted.
+// It returns -1 if the port is omitted and the scheme has no known default port.
+func (v Origin) Port() int {
+	if v.IsOpaque() {
+		return -1
+	}
+	if p := URL(v).Port(); p >= 0 {
+		return p
+	}
+	return defaultPortOf(v.Scheme())
+}
+
+// SameOrigin reports whether two origins have the same scheme, host, and port.
+// Opaque origins are never the same origin as any other origin.
+func (v Origin) SameOrigin(other Origin) bool {
+	if v.IsOpaque() || other.IsOpaque() {
+		return false
+	}
+	return v.Scheme() == other.Scheme() &&
+		strings.EqualFold(string(v.Host()), string(other.Host())) &&
+		v.Port() == other.Port()
+}
+
+// SameSite reports whether two origins have the same scheme and the same site,
+// where the site of a domain is its registrable domain (eTLD+1) by [DefaultPublicSuffixList].
+// Hosts without registrable domain, such as IP addresses, must be equal.
+// Ports are not considered.
+//
+// Examples:
+//
+//	https://a.example.com and https://b.example.com:8443 are same site
+//	https://a.example.com and http://a.example.com are not same site
+//	https://a.github.io and https://b.github.io are not same site
+func (v Origin) SameSite(other Origin) bool {
+	if v.IsOpaque() || other.IsOpaque() {
+		return false
+	}
+	if v.Scheme() != other.Scheme() {
+		return false
+	}
+	return strings.EqualFold(string(v.site()), string(other.site()))
+}
+
+func (v Origin) site() Host {
+	h := v.Host()
+	d, ok := h.Domain()
+	if !ok {
+		return h
+	}
+	if r, ok := d.Relative().Registrable(); ok {
+		return Host(r)
+	}
+	return Host(d.Relative())
+}
+
+// OriginPattern represents an origin whose host may have a wildcard as its leftmost label.
+// The wildcard matches exactly one label as [DomainPattern] does.
+//
+// Examples:
+//
+//	https://example.com
+//	https://*.example.com
+//	http://*.example.com:8080
+type OriginPattern string
+
+func (v OriginPattern) Sanitize() (OriginPattern, error) {
+	s := string(v)
+	scheme, rest, ok := strings.Cut(s, "://")
+	if !ok {
+		return "", errors.New("missing scheme")
+	}
+	wildcard := strings.HasPrefix(rest, "*.")
+	if i := strings.LastIndex(rest, "*"); i > 0 || (i == 0 && !wildcard) {
+		return "", errPosF(len(scheme)+len("://")+i, "wildcard is allowed only as the leftmost label")
+	}
+	if !wildcard {
+		w, err := Origin(s).Sanitize()
+		if err != nil {
+			return "", err
+		}
+		if w.IsOpaque() {
+			return "", errors.New("opaque origin cannot be a pattern")
+		}
+		return OriginPattern(w), nil
+	}
+
+	// Sanitize with a placeholder label which is replaced back to the wildcard.
+	const placeholder = "wildcard"
+	w, err := Origin(scheme + "://" + placeholder + rest[1:]).Sanitize()
+	if err != nil {
+		return "", err
+	}
+
+	h := w.Host()
+	d, _ := h.Domain()
+	p, err := DomainPattern("*" + strings.TrimPrefix(string(d), placeholder)).Sanitize()
+	if err != nil {
+		return "", err
+	}
+
+	return OriginPattern(originOf(w.Scheme(), Host(p), URL(w).Port())), nil
+}
+
+// Match reports whether the origin matches the pattern.
+func (v OriginPattern) Match(origin Origin) bool {
+	if origin.IsOpaque() {
+		return false
+	}
+
+	o := Origin(v)
+	if o.Scheme() != origin.Scheme() || o.Port() != origin.Port() {
+		return false
+	}
+
+	h := string(o.Host())
+	if !strings.HasPrefix(h, "*.") {
+		return strings.EqualFold(h, string(origin.Host()))
+	}
+	return DomainPattern(h).Match(origin.Host())
+}
+
+// OriginAllowlist is a list of origin patterns such as the allowed origins of CORS.
+type OriginAllowlist []OriginPattern
+
+func (v OriginAllowlist) Sanitize() (OriginAllowlist, error) {
+	rs := make(OriginAllowlist, len(v))
+	for i, p := range v {
+		w, err := p.Sanitize()
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		rs[i] = w
+	}
+	return rs, nil
+}
+
+// Allows reports whether the origin matches any of the patterns.
+func (v OriginAllowlist) Allows(origin Origin) bool {
+	for _, p := range v {
+		if p.Match(origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package xddr
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+//go:generate curl -fsSL -o public_suffix_list.dat https://publicsuffix.org/list/public_suffix_list.dat
+
+//go:embed public_suffix_list.dat
+var publicSuffixListDat []byte
+
+type publicSuffixRule uint8
+
+const (
+	publicSuffixRuleNormal publicSuffixRule = 1 << iota
+	publicSuffixRuleWildcard
+	publicSuffixRuleException
+)
+
+// PublicSuffixList is a set of rules of the Public Suffix List.
+// See https://publicsuffix.org/list/ for the format and the algorithm.
+type PublicSuffixList struct {
+	// Rules keyed by the domain without "*." or "!" prefix.
+	rules map[string]publicSuffixRule
+}
+
+// ParsePublicSuffixList parses the Public Suffix List in its original format.
+// Position of the error is the line number.
+func ParsePublicSuffixList(r io.Reader) (*PublicSuffixList, error) {
+	l := &PublicSuffixList{rules: map[string]publicSuffixRule{}}
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		// Rules end with the first whitespace.
+		rule, _, _ := strings.Cut(line, " ")
+		rule, _, _ = strings.Cut(rule, "\t")
+		rule = strings.ToLower(rule)
+
+		kind := publicSuffixRuleNormal
+		if r, ok := strings.CutPrefix(rule, "!"); ok {
+			kind = publicSuffixRuleException
+			rule = r
+		} else if r, ok := strings.CutPrefix(rule, "*."); ok {
+			kind = publicSuffixRuleWildcard
+			rule = r
+		}
+		if rule == "" || strings.HasPrefix(rule, ".") || strings.HasSuffix(rule, ".") || strings.Contains(rule, "..") {
+			return nil, errPosF(n, "invalid rule %q", line)
+		}
+		if strings.Contains(rule, "*") {
+			return nil, errPosF(n, "wildcard is allowed only for the leftmost label: %q", line)
+		}
+
+		// Domains are in ASCII, so IDN rules are matched in their ACE form.
+		rule, err := toASCIIDomain(rule)
+		if err != nil {
+			return nil, errPosF(n, "invalid rule %q: %w", line, err)
+		}
+
+		l.rules[rule] |= kind
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(l.rules) == 0 {
+		return nil, fmt.Errorf("no rules")
+	}
+
+	return l, nil
+}
+
+// LoadPublicSuffixList reads the Public Suffix List from the file.
+func LoadPublicSuffixList(name string) (*PublicSuffixList, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	l, err := ParsePublicSuffixList(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return l, nil
+}
+
+var (
+	publicSuffixList         atomic.Pointer[PublicSuffixList]
+	publicSuffixListEmbedded = sync.OnceValue(func() *PublicSuffixList {
+		l, err := ParsePublicSuffixList(bytes.NewReader(publicSuffixListDat))
+		if err != nil {
+			panic(fmt.Sprintf("embedded public suffix list: %s", err))
+		}
+		return l
+	})
+)
+
+// DefaultPublicSuffixList returns the list used by [Domain.PublicSuffix] and its friends.
+// It is the embedded snapshot unless replaced by [SetPublicSuffixList].
+func DefaultPublicSuffixList() *PublicSuffixList {
+	if l := publicSuffixList.Load(); l != nil {
+		return l
+	}
+	return publicSuffixListEmbedded()
+}
+
+// SetPublicSuffixList replaces the default list, e.g. with a newer one loaded at runtime.
+// Passing nil restores the embedded snapshot.
+func SetPublicSuffixList(l *PublicSuffixList) {
+	publicSuffixList.Store(l)
+}
+
+// publicSuffixLen returns the number of labels of the public suffix of the labels.
+func (l *PublicSuffixList) publicSuffixLen(labels []string) int {
+	n := 0
+	for i := range labels {
+		suffix := strings.Join(labels[i:], ".")
+		rule := l.rules[suffix]
+		if rule&publicSuffixRuleException != 0 {
+			// Exception rule always prevails.
+			return len(labels) - i - 1
+		}
+		if n > 0 {
+			continue
+		}
+		if rule&publicSuffixRuleNormal != 0 {
+			n = len(labels) - i
+			continue
+		}
+		if i+1 < len(labels) && l.rules[strings.Join(labels[i+1:], ".")]&publicSuffixRuleWildcard != 0 {
+			n = len(labels) - i
+		}
+	}
+	if n == 0 {
+		// Implicit "*" rule.
+		n = 1
+	}
+	return n
+}
+
+// split returns labels of the domain without the root label and whether the domain is absolute.
+func (l *PublicSuffixList) split(v Domain) ([]string, string) {
+	s, ok := strings.CutSuffix(string(v), ".")
+	root := ""
+	if ok {
+		root = "."
+	}
+	return strings.Split(s, "."), root
+}
+
+// PublicSuffix returns the public suffix (eTLD) of the domain.
+// The domain is expected to be sanitized.
+func (l *PublicSuffixList) PublicSuffix(v Domain) Domain {
+	labels, root := l.split(v)
+	n := l.publicSuffixLen(labels)
+	return Domain(strings.Join(labels[len(labels)-n:], ".") + root)
+}
+
+// Registrable returns the registrable domain (eTLD+1) of the domain.
+// It returns false if the domain is a public suffix itself.
+func (l *PublicSuffixList) Registrable(v Domain) (Domain, bool) {
+	labels, root := l.split(v)
+	n := l.publicSuffixLen(labels)
+	if n >= len(labels) {
+		return "", false
+	}
+	return Domain(strings.Join(labels[len(labels)-n-1:], ".") + root), true
+}
+
+// IsPublicSuffix reports whether the domain is a public suffix.
+func (l *PublicSuffixList) IsPublicSuffix(v Domain) bool {
+	labels, _ := l.split(v)
+	return l.publicSuffixLen(labels) >= len(labels)
+}
+
+// PublicSuffix returns the public suffix (eTLD) of the domain using [DefaultPublicSuffixList].
+//
+// Examples:
+//
+//	example.com       -> com
+//	www.example.co.uk -> co.uk
+//	foo.github.io     -> github.io
+func (v Domain) PublicSuffix() Domain {
+	return DefaultPublicSuffixList().PublicSuffix(v)
+}
+
+// Registrable returns the registrable domain (eTLD+1) of the domain using [DefaultPublicSuffixList].
+// It returns false if the domain is a public suffix itself.
+//
+// Examples:
+//
+//	www.example.com   -> example.com
+//	www.example.co.uk -> example.co.uk
+//	co.uk             -> (false)
+func (v Domain) Registrable() (Domain, bool) {
+	return DefaultPublicSuffixList().Registrable(v)
+}
+
+// IsPublicSuffix reports whether the domain is a public suffix using [DefaultPublicSuffixList].
+func (v Domain) IsPublicSuffix() bool {
+	return DefaultPublicSuffixList().IsPublicSuffix(v)
+}
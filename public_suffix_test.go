@@ -0,0 +1,106 @@
+package xddr_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestPublicSuffix(t *testing.T) {
+	t.Run("Embedded", func(t *testing.T) {
+		for _, tc := range []struct {
+			given       xddr.Domain
+			suffix      xddr.Domain
+			registrable xddr.Domain
+		}{
+			{"com", "com", ""},
+			{"example.com", "com", "example.com"},
+			{"www.example.com", "com", "example.com"},
+			{"www.example.com.", "com.", "example.com."},
+			{"co.uk", "co.uk", ""},
+			{"www.example.co.uk", "co.uk", "example.co.uk"},
+			{"foo.github.io", "github.io", "foo.github.io"},
+			{"a.b.foo.github.io", "github.io", "foo.github.io"},
+			{"example.unknowntld", "unknowntld", "example.unknowntld"},
+
+			// Wildcard and exception rules.
+			{"ck", "ck", ""},
+			{"foo.ck", "foo.ck", ""},
+			{"bar.foo.ck", "foo.ck", "bar.foo.ck"},
+			{"www.ck", "ck", "www.ck"},
+			{"a.www.ck", "ck", "www.ck"},
+			{"foo.kawasaki.jp", "foo.kawasaki.jp", ""},
+			{"city.kawasaki.jp", "kawasaki.jp", "city.kawasaki.jp"},
+
+			// IDN rules, e.g. "公司.cn" and "香港".
+			{"xn--55qx5d.cn", "xn--55qx5d.cn", ""},
+			{"foo.xn--55qx5d.cn", "xn--55qx5d.cn", "foo.xn--55qx5d.cn"},
+			{"foo.xn--55qx5d.xn--j6w193g", "xn--55qx5d.xn--j6w193g", "foo.xn--55qx5d.xn--j6w193g"},
+		} {
+			t.Run(fmt.Sprintf("Domain(%q).PublicSuffix()=%q", tc.given, tc.suffix), func(t *testing.T) {
+				AssertEq(t, tc.given.PublicSuffix(), tc.suffix)
+
+				v, ok := tc.given.Registrable()
+				AssertEq(t, ok, tc.registrable != "")
+				AssertEq(t, v, tc.registrable)
+				AssertEq(t, tc.given.IsPublicSuffix(), tc.registrable == "")
+			})
+		}
+	})
+	t.Run("Parse", func(t *testing.T) {
+		l, err := xddr.ParsePublicSuffixList(strings.NewReader(`
+// comment
+com
+*.example
+!www.example
+example.org trailing text is ignored
+*.例子
+!www.例子
+bücher.de
+`))
+		AssertNoError(t, err)
+		AssertEq(t, l.PublicSuffix("a.b.com"), "com")
+		AssertEq(t, l.PublicSuffix("a.b.example"), "b.example")
+		AssertEq(t, l.PublicSuffix("a.www.example"), "example")
+		AssertEq(t, l.PublicSuffix("a.example.org"), "example.org")
+		AssertEq(t, l.PublicSuffix("a.b.xn--fsqu00a"), "b.xn--fsqu00a")
+		AssertEq(t, l.PublicSuffix("a.www.xn--fsqu00a"), "xn--fsqu00a")
+		AssertEq(t, l.PublicSuffix("a.xn--bcher-kva.de"), "xn--bcher-kva.de")
+
+		for _, tc := range []struct {
+			given string
+			err   string
+		}{
+			{"", "no rules"},
+			{"com\n.com\n", "[2]: invalid rule"},
+			{"com\nfoo..com\n", "[2]: invalid rule"},
+			{"a.*.com\n", "[1]: wildcard is allowed only for the leftmost label"},
+		} {
+			t.Run(fmt.Sprintf("ParsePublicSuffixList(%q) -> %q", tc.given, tc.err), func(t *testing.T) {
+				_, err := xddr.ParsePublicSuffixList(strings.NewReader(tc.given))
+				AssertErrorContains(t, err, tc.err)
+			})
+		}
+	})
+	t.Run("SetPublicSuffixList", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "public_suffix_list.dat")
+		err := os.WriteFile(p, []byte("com\nexample.com\n"), 0o644)
+		AssertNoError(t, err)
+
+		l, err := xddr.LoadPublicSuffixList(p)
+		AssertNoError(t, err)
+
+		xddr.SetPublicSuffixList(l)
+		defer xddr.SetPublicSuffixList(nil)
+
+		AssertEq(t, xddr.Domain("foo.example.com").PublicSuffix(), "example.com")
+		AssertEq(t, xddr.Domain("foo.co.uk").PublicSuffix(), "uk")
+
+		xddr.SetPublicSuffixList(nil)
+		AssertEq(t, xddr.Domain("foo.co.uk").PublicSuffix(), "co.uk")
+	})
+}
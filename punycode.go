@@ -0,0 +1,129 @@
+package xddr
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// Parameters of Punycode for IDNA (RFC 3492 §5).
+const (
+	punycodeBase        = 36
+	punycodeTMin        = 1
+	punycodeTMax        = 26
+	punycodeSkew        = 38
+	punycodeDamp        = 700
+	punycodeInitialBias = 72
+	punycodeInitialN    = 128
+)
+
+// toASCIIDomain converts each label of the domain which has non-ASCII characters
+// into its ACE form, "xn--" followed by Punycode of the label.
+// Labels are expected to be lowercased and normalized already,
+// so it does not do the mapping of IDNA.
+func toASCIIDomain(s string) (string, error) {
+	labels := strings.Split(s, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		v, err := encodePunycode(label)
+		if err != nil {
+			return "", err
+		}
+		labels[i] = "xn--" + v
+	}
+	return strings.Join(labels, "."), nil
+}
+
+func isASCII(s string) bool {
+	for _, c := range []byte(s) {
+		if c >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// encodePunycode encodes the string into Punycode as described in RFC 3492 §6.3.
+func encodePunycode(s string) (string, error) {
+	runes := []rune(s)
+
+	b := strings.Builder{}
+	for _, r := range runes {
+		if r < 0x80 {
+			b.WriteByte(byte(r))
+		}
+	}
+	basic := b.Len()
+	if basic > 0 {
+		b.WriteByte('-')
+	}
+
+	n := rune(punycodeInitialN)
+	delta := 0
+	bias := punycodeInitialBias
+	for h := basic; h < len(runes); {
+		m := rune(math.MaxInt32)
+		for _, r := range runes {
+			if r >= n && r < m {
+				m = r
+			}
+		}
+		if int(m-n) > (math.MaxInt32-delta)/(h+1) {
+			return "", errors.New("punycode overflow")
+		}
+		delta += int(m-n) * (h + 1)
+		n = m
+
+		for _, r := range runes {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+
+			q := delta
+			for k := punycodeBase; ; k += punycodeBase {
+				t := min(max(k-bias, punycodeTMin), punycodeTMax)
+				if q < t {
+					break
+				}
+				b.WriteByte(punycodeDigit(t + (q-t)%(punycodeBase-t)))
+				q = (q - t) / (punycodeBase - t)
+			}
+			b.WriteByte(punycodeDigit(q))
+			bias = punycodeAdapt(delta, h+1, h == basic)
+			delta = 0
+			h++
+		}
+		delta++
+		n++
+	}
+	return b.String(), nil
+}
+
+func punycodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+// punycodeAdapt is the bias adaptation function of RFC 3492 §6.1.
+func punycodeAdapt(delta, points int, first bool) int {
+	if first {
+		delta /= punycodeDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / points
+
+	k := 0
+	for delta > ((punycodeBase-punycodeTMin)*punycodeTMax)/2 {
+		delta /= punycodeBase - punycodeTMin
+		k += punycodeBase
+	}
+	return k + (punycodeBase-punycodeTMin+1)*delta/(delta+punycodeSkew)
+}
@@ -0,0 +1,179 @@
+package xddr
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResolvConf represents the resolver configuration file such as "/etc/resolv.conf" (see resolv.conf(5)).
+type ResolvConf struct {
+	// Nameservers in the order of the file.
+	// Port 53 is used if the port is not given.
+	Nameservers []IPPort
+
+	// Search list for host-name lookup.
+	// The "domain" keyword is treated as a search list with single domain
+	// and the last "domain" or "search" line wins as the resolver does.
+	Search []Domain
+
+	Options ResolvConfOptions
+}
+
+type ResolvConfOptions struct {
+	// Number of dots which must appear in a name before an initial absolute query is made.
+	// Defaults to 1 and capped at 15.
+	Ndots int
+
+	// Amount of time to wait for a response from a nameserver.
+	// Defaults to 5 seconds and capped at 30 seconds.
+	Timeout time.Duration
+
+	// Number of times to query the nameservers before giving up.
+	// Defaults to 2 and capped at 5.
+	Attempts int
+
+	// Options which are not interpreted, as written in the file.
+	Others []string
+}
+
+// ParseResolvConf parses the resolver configuration.
+// Unknown keywords are ignored as the resolver does.
+// Position of the error is the line number.
+func ParseResolvConf(r io.Reader) (*ResolvConf, error) {
+	c := &ResolvConf{
+		Nameservers: []IPPort{},
+		Search:      []Domain{},
+		Options: ResolvConfOptions{
+			Ndots:    1,
+			Timeout:  5 * time.Second,
+			Attempts: 2,
+			Others:   []string{},
+		},
+	}
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		// Comments start with '#' or ';' anywhere in the line.
+		line := s.Text()
+		if i := strings.IndexAny(line, "#;"); i >= 0 {
+			line = line[:i]
+		}
+		fs := strings.Fields(line)
+		if len(fs) == 0 {
+			continue
+		}
+
+		keyword, args := fs[0], fs[1:]
+		switch keyword {
+		case "nameserver":
+			if len(args) != 1 {
+				return nil, errPosF(n, "nameserver must have exactly one address")
+			}
+			v, err := parseNameserver(args[0])
+			if err != nil {
+				return nil, errPosF(n, "invalid nameserver %q: %w", args[0], err)
+			}
+			c.Nameservers = append(c.Nameservers, v)
+
+		case "domain", "search":
+			if len(args) == 0 {
+				return nil, errPosF(n, "%s must have at least one domain", keyword)
+			}
+			if keyword == "domain" && len(args) > 1 {
+				return nil, errPosF(n, "domain must have exactly one domain")
+			}
+			ds := make([]Domain, 0, len(args))
+			for _, arg := range args {
+				d, err := Domain(arg).Sanitize()
+				if err != nil {
+					return nil, errPosF(n, "invalid domain %q: %w", arg, err)
+				}
+				ds = append(ds, d)
+			}
+			c.Search = ds
+
+		case "options":
+			for _, arg := range args {
+				if err := c.Options.set(arg); err != nil {
+					return nil, errPosF(n, "invalid option %q: %w", arg, err)
+				}
+			}
+
+		default:
+			// Unknown keywords are ignored as the resolver does,
+			// including "sortlist" which is not supported by most of resolvers.
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// LoadResolvConf reads the resolver configuration from the file.
+func LoadResolvConf(name string) (*ResolvConf, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	c, err := ParseResolvConf(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return c, nil
+}
+
+// parseNameserver parses the address of the nameserver which may have a port.
+//
+// Examples:
+//
+//	192.0.2.1
+//	192.0.2.1:5353
+//	fe80::1%eth0
+//	[2001:db8::1]:5353
+func parseNameserver(s string) (IPPort, error) {
+	if s[0] != '[' {
+		if ip, err := IP(s).Sanitize(); err == nil {
+			return ipPortOf(ip, 53), nil
+		} else if strings.Count(s, ":") != 1 {
+			return "", err
+		}
+	}
+	return IPPort(s).Sanitize()
+}
+
+func (o *ResolvConfOptions) set(s string) error {
+	name, value, ok := strings.Cut(s, ":")
+	switch name {
+	case "ndots", "timeout", "attempts":
+	default:
+		o.Others = append(o.Others, s)
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("missing value")
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return fmt.Errorf("must be a non-negative number")
+	}
+
+	switch name {
+	case "ndots":
+		o.Ndots = min(n, 15)
+	case "timeout":
+		o.Timeout = time.Duration(min(n, 30)) * time.Second
+	case "attempts":
+		o.Attempts = min(n, 5)
+	}
+	return nil
+}
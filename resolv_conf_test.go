@@ -0,0 +1,115 @@
+package xddr_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestParseResolvConf(t *testing.T) {
+	t.Run("full", func(t *testing.T) {
+		c, err := xddr.ParseResolvConf(strings.NewReader(`# Generated by NetworkManager
+; another comment
+domain corp.example.com
+search Example.com svc.cluster.local.
+nameserver 192.0.2.53 # trailing comment
+nameserver 2001:db8::53;another trailing comment
+nameserver fe80::1%eth0
+nameserver [2001:db8::35]:5353
+nameserver 192.0.2.35:5353
+options ndots:5 timeout:2
+options attempts:10 rotate edns0
+sortlist 130.155.160.0/255.255.240.0
+lookup file bind
+`))
+		AssertNoError(t, err)
+
+		want := []xddr.IPPort{
+			"192.0.2.53:53",
+			"[2001:db8::53]:53",
+			"[fe80::1%eth0]:53",
+			"[2001:db8::35]:5353",
+			"192.0.2.35:5353",
+		}
+		Assert(t, slices.Equal(c.Nameservers, want), "want %v, got %v", want, c.Nameservers)
+		Assert(t, slices.Equal(c.Search, []xddr.Domain{"example.com", "svc.cluster.local."}), "got %v", c.Search)
+		AssertEq(t, c.Options.Ndots, 5)
+		AssertEq(t, c.Options.Timeout, 2*time.Second)
+		AssertEq(t, c.Options.Attempts, 5)
+		Assert(t, slices.Equal(c.Options.Others, []string{"rotate", "edns0"}), "got %v", c.Options.Others)
+	})
+	t.Run("defaults", func(t *testing.T) {
+		c, err := xddr.ParseResolvConf(strings.NewReader("nameserver 127.0.0.53\n"))
+		AssertNoError(t, err)
+		AssertEq(t, len(c.Search), 0)
+		AssertEq(t, c.Options.Ndots, 1)
+		AssertEq(t, c.Options.Timeout, 5*time.Second)
+		AssertEq(t, c.Options.Attempts, 2)
+	})
+	t.Run("unknown keywords are ignored", func(t *testing.T) {
+		c, err := xddr.ParseResolvConf(strings.NewReader("nameservers 192.0.2.1\nnameserver 192.0.2.2\n"))
+		AssertNoError(t, err)
+		Assert(t, slices.Equal(c.Nameservers, []xddr.IPPort{"192.0.2.2:53"}), "got %v", c.Nameservers)
+	})
+	t.Run("last search list wins", func(t *testing.T) {
+		c, err := xddr.ParseResolvConf(strings.NewReader("search a.example.com b.example.com\ndomain c.example.com\n"))
+		AssertNoError(t, err)
+		Assert(t, slices.Equal(c.Search, []xddr.Domain{"c.example.com"}), "got %v", c.Search)
+	})
+	for _, tc := range [][]string{
+		{"[1]: nameserver must have exactly one address",
+			"nameserver",
+			"nameserver 192.0.2.1 192.0.2.2",
+		},
+		{"[2]: invalid nameserver",
+			"# comment\nnameserver ns.example.com",
+			"\nnameserver 192.0.2.256",
+			"\nnameserver 192.0.2.1:65536",
+			"\nnameserver [::1]",
+		},
+		{"[1]: search must have at least one domain",
+			"search",
+		},
+		{"[1]: domain must have exactly one domain",
+			"domain a.example.com b.example.com",
+		},
+		{"[1]: invalid domain",
+			"search example.com foo_bar.com",
+		},
+		{"[1]: invalid option \"ndots\": missing value",
+			"options ndots",
+		},
+		{"must be a non-negative number",
+			"options timeout:-1",
+			"options attempts:x",
+		},
+	} {
+		for _, given := range tc[1:] {
+			t.Run(fmt.Sprintf("ParseResolvConf(%q) -> %q", given, tc[0]), func(t *testing.T) {
+				_, err := xddr.ParseResolvConf(strings.NewReader(given))
+				AssertErrorContains(t, err, tc[0])
+			})
+		}
+	}
+}
+
+func TestLoadResolvConf(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "resolv.conf")
+	err := os.WriteFile(p, []byte("nameserver 192.0.2.53\nsearch example.com\n"), 0o644)
+	AssertNoError(t, err)
+
+	c, err := xddr.LoadResolvConf(p)
+	AssertNoError(t, err)
+	Assert(t, slices.Equal(c.Nameservers, []xddr.IPPort{"192.0.2.53:53"}), "got %v", c.Nameservers)
+
+	err = os.WriteFile(p, []byte("nameserver\n"), 0o644)
+	AssertNoError(t, err)
+	_, err = xddr.LoadResolvConf(p)
+	AssertErrorContains(t, err, p+": [1]: nameserver")
+}
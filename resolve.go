@@ -0,0 +1,328 @@
+package xddr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Resolver resolves domain names into IP addresses.
+type Resolver interface {
+	// LookupIP returns the IP addresses of the host.
+	// The network must be one of "ip", "ip4", or "ip6".
+	LookupIP(ctx context.Context, network string, host Domain) ([]IP, error)
+}
+
+// SRVResolver is a [Resolver] which also resolves SRV records.
+type SRVResolver interface {
+	Resolver
+
+	// LookupSRV returns the SRV records of the name in the order they should be tried.
+	LookupSRV(ctx context.Context, name SRVName) ([]SRV, error)
+}
+
+// SRV represents a DNS SRV record (RFC 2782).
+type SRV struct {
+	Target   Domain
+	Port     int
+	Priority uint16
+	Weight   uint16
+}
+
+func errNoSuchHost(host string) error {
+	return &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// filterIPs returns the addresses of the given network.
+func filterIPs(ips []IP, network string) ([]IP, error) {
+	switch network {
+	case "ip":
+		return ips, nil
+	case "ip4", "ip6":
+	default:
+		return nil, fmt.Errorf("unknown network %q", network)
+	}
+
+	rs := make([]IP, 0, len(ips))
+	for _, ip := range ips {
+		if _, ok := ip.V4(); ok == (network == "ip4") {
+			rs = append(rs, ip)
+		}
+	}
+	return rs, nil
+}
+
+// equalDomain reports whether two domains are the same ignoring case and the trailing dot.
+func equalDomain(a, b Domain) bool {
+	return strings.EqualFold(string(a.Relative()), string(b.Relative()))
+}
+
+// NetResolver is a [SRVResolver] backed by [net.Resolver].
+type NetResolver struct {
+	// Resolver to use. [net.DefaultResolver] is used if nil.
+	Resolver *net.Resolver
+}
+
+func (r NetResolver) resolver() *net.Resolver {
+	if r.Resolver == nil {
+		return net.DefaultResolver
+	}
+	return r.Resolver
+}
+
+func (r NetResolver) LookupIP(ctx context.Context, network string, host Domain) ([]IP, error) {
+	addrs, err := r.resolver().LookupNetIP(ctx, network, string(host))
+	if err != nil {
+		return nil, err
+	}
+
+	rs := make([]IP, len(addrs))
+	for i, addr := range addrs {
+		rs[i] = IP(addr.Unmap().String())
+	}
+	return rs, nil
+}
+
+func (r NetResolver) LookupSRV(ctx context.Context, name SRVName) ([]SRV, error) {
+	_, srvs, err := r.resolver().LookupSRV(ctx, "", "", string(name))
+	if err != nil {
+		return nil, err
+	}
+
+	rs := make([]SRV, len(srvs))
+	for i, srv := range srvs {
+		rs[i] = SRV{
+			Target:   Domain(srv.Target),
+			Port:     int(srv.Port),
+			Priority: srv.Priority,
+			Weight:   srv.Weight,
+		}
+	}
+	return rs, nil
+}
+
+// StaticResolver is a [SRVResolver] which resolves names from fixed tables.
+// Names are matched case-insensitively regardless of the trailing dot.
+// It is useful for tests.
+type StaticResolver struct {
+	Hosts map[Domain][]IP
+	SRV   map[SRVName][]SRV
+}
+
+func (r StaticResolver) LookupIP(ctx context.Context, network string, host Domain) ([]IP, error) {
+	for k, ips := range r.Hosts {
+		if equalDomain(k, host) {
+			return filterIPs(ips, network)
+		}
+	}
+	return nil, errNoSuchHost(string(host))
+}
+
+func (r StaticResolver) LookupSRV(ctx context.Context, name SRVName) ([]SRV, error) {
+	for k, srvs := range r.SRV {
+		if equalDomain(Domain(k), Domain(name)) {
+			return srvs, nil
+		}
+	}
+	return nil, errNoSuchHost(string(name))
+}
+
+// HostsResolver is a [Resolver] which resolves names using a hosts file such as "/etc/hosts".
+// The file is read on every lookup so changes are visible immediately.
+// Invalid lines are skipped as the system resolver does, unlike [ParseHostsFile].
+type HostsResolver struct {
+	// Path of the hosts file. "/etc/hosts" is used if empty.
+	Path string
+}
+
+func (r HostsResolver) LookupIP(ctx context.Context, network string, host Domain) ([]IP, error) {
+	p := r.Path
+	if p == "" {
+		p = "/etc/hosts"
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ips, err := lookupHosts(f, host)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p, err)
+	}
+	if len(ips) == 0 {
+		return nil, errNoSuchHost(string(host))
+	}
+	return filterIPs(ips, network)
+}
+
+func resolveHost(ctx context.Context, r Resolver, host Host, port int) ([]IPPort, error) {
+	if ip, ok := host.IP(); ok {
+		return []IPPort{ipPortOf(ip, port)}, nil
+	}
+
+	d, ok := host.Domain()
+	if !ok {
+		return nil, fmt.Errorf("invalid host %q", host)
+	}
+
+	ips, err := r.LookupIP(ctx, "ip", d)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, errNoSuchHost(string(d))
+	}
+
+	rs := make([]IPPort, len(ips))
+	for i, ip := range ips {
+		rs[i] = ipPortOf(ip, port)
+	}
+	return rs, nil
+}
+
+// ResolveHostPort resolves the host into IP endpoints with the port.
+func ResolveHostPort(ctx context.Context, r Resolver, v HostPort) ([]IPPort, error) {
+	h, port, err := v.Split()
+	if err != nil {
+		return nil, err
+	}
+	return resolveHost(ctx, r, h, port)
+}
+
+// ResolveHTTP resolves the host of the URL into IP endpoints.
+// The default port of the scheme is used if the port is omitted.
+func ResolveHTTP(ctx context.Context, r Resolver, v HTTP) ([]IPPort, error) {
+	return resolveHost(ctx, r, URL(v).Host(), v.Port())
+}
+
+// ResolveGRPC resolves the gRPC target into IP endpoints.
+// The "dns", "ipv4", and "ipv6" schemes are supported and 443 is used if the port is omitted,
+// as gRPC does.
+// The authority of the "dns" scheme, which names a DNS server, is ignored
+// and the given resolver is used instead.
+//
+// Examples:
+//
+//	dns:///example.com:50051
+//	ipv4:198.51.100.1:50051,198.51.100.2
+//	ipv6:[2001:db8::1]:50051,2001:db8::2
+func ResolveGRPC(ctx context.Context, r Resolver, v GRPC) ([]IPPort, error) {
+	hps, err := grpcEndpoints(v)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := []IPPort{}
+	for _, hp := range hps {
+		vs, err := ResolveHostPort(ctx, r, hp)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, vs...)
+	}
+	return rs, nil
+}
+
+// grpcEndpoints returns the endpoints of the gRPC target of "dns", "ipv4", or "ipv6" scheme.
+// Hosts of "ipv4" and "ipv6" schemes are IP addresses.
+func grpcEndpoints(v GRPC) ([]HostPort, error) {
+	const defaultPort = 443
+
+	scheme, rest, _ := strings.Cut(string(v), ":")
+	switch scheme {
+	case "dns":
+		if a, ok := strings.CutPrefix(rest, "//"); ok {
+			_, rest, _ = strings.Cut(a, "/")
+		}
+		h, port, err := splitHostDefaultPort(rest, defaultPort)
+		if err != nil {
+			return nil, err
+		}
+		return []HostPort{HostPort(string(h) + ":" + strconv.Itoa(port))}, nil
+
+	case "ipv4", "ipv6":
+		rs := []HostPort{}
+		for i, e := range strings.Split(rest, ",") {
+			h, port, err := splitHostDefaultPort(e, defaultPort)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			if (scheme == "ipv4" && !h.IsIPv4()) || (scheme == "ipv6" && !h.IsIPv6()) {
+				return nil, fmt.Errorf("[%d]: not an %s address: %q", i, scheme, e)
+			}
+			rs = append(rs, HostPort(string(h)+":"+strconv.Itoa(port)))
+		}
+		return rs, nil
+	}
+
+	w, err := v.Sanitize()
+	if err != nil {
+		return nil, err
+	}
+	if SchemeOf(w) != "dns" {
+		return nil, fmt.Errorf("scheme %q cannot be resolved into IP endpoints", SchemeOf(w))
+	}
+	return grpcEndpoints(w)
+}
+
+// splitHostDefaultPort splits "host[:port]" where IPv6 address without port
+// can be given without square brackets.
+func splitHostDefaultPort(s string, port int) (Host, int, error) {
+	if s == "" {
+		return "", 0, errors.New("missing host")
+	}
+
+	h := s
+	if strings.Count(s, ":") > 1 && s[0] != '[' {
+		// Bare IPv6 address.
+	} else if i := strings.LastIndex(s, ":"); i >= 0 && i > strings.LastIndex(s, "]") {
+		h = s[:i]
+		n, err := strconv.Atoi(s[i+1:])
+		if err != nil || !(0 <= n && n <= 65535) {
+			return "", 0, fmt.Errorf("invalid port %q", s[i+1:])
+		}
+		port = n
+	}
+
+	if strings.Contains(h, ":") && h[0] != '[' {
+		h = "[" + h + "]"
+	}
+	w, err := Host(h).Sanitize()
+	if err != nil {
+		return "", 0, err
+	}
+	return w, port, nil
+}
+
+// ResolveSRV resolves the SRV name into IP endpoints in the order of the SRV records.
+// Each target is resolved using the same resolver.
+func ResolveSRV(ctx context.Context, r SRVResolver, name SRVName) ([]IPPort, error) {
+	srvs, err := r.LookupSRV(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := []IPPort{}
+	for _, srv := range srvs {
+		if srv.Target == "." {
+			// The service is decidedly not available (RFC 2782).
+			continue
+		}
+
+		vs, err := resolveHost(ctx, r, Host(srv.Target), srv.Port)
+		if err != nil {
+			return nil, fmt.Errorf("resolve target %q: %w", srv.Target, err)
+		}
+		rs = append(rs, vs...)
+	}
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("service %q is not available", name)
+	}
+	return rs, nil
+}
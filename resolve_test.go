@@ -0,0 +1,212 @@
+package xddr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+var testResolver = xddr.StaticResolver{
+	Hosts: map[xddr.Domain][]xddr.IP{
+		"example.com":   {"192.0.2.1", "2001:db8::1"},
+		"a.example.com": {"192.0.2.10"},
+		"b.example.com": {"2001:db8::b"},
+	},
+	SRV: map[xddr.SRVName][]xddr.SRV{
+		"_grpc._tcp.example.com": {
+			{Target: "a.example.com.", Port: 50051, Priority: 10, Weight: 5},
+			{Target: "b.example.com.", Port: 50052, Priority: 20, Weight: 5},
+		},
+		"_none._tcp.example.com": {
+			{Target: "."},
+		},
+	},
+}
+
+func TestStaticResolver(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		network string
+		host    xddr.Domain
+		want    []xddr.IP
+	}{
+		{"ip", "example.com", []xddr.IP{"192.0.2.1", "2001:db8::1"}},
+		{"ip", "EXAMPLE.com.", []xddr.IP{"192.0.2.1", "2001:db8::1"}},
+		{"ip4", "example.com", []xddr.IP{"192.0.2.1"}},
+		{"ip6", "example.com", []xddr.IP{"2001:db8::1"}},
+		{"ip4", "b.example.com", []xddr.IP{}},
+	} {
+		t.Run(fmt.Sprintf("LookupIP(%q, %q)=%v", tc.network, tc.host, tc.want), func(t *testing.T) {
+			ips, err := testResolver.LookupIP(ctx, tc.network, tc.host)
+			AssertNoError(t, err)
+			Assert(t, slices.Equal(ips, tc.want), "want %v, got %v", tc.want, ips)
+		})
+	}
+	t.Run("not found", func(t *testing.T) {
+		_, err := testResolver.LookupIP(ctx, "ip", "c.example.com")
+		var e *net.DNSError
+		Assert(t, errors.As(err, &e), "want DNS error, got %v", err)
+		Assert(t, e.IsNotFound, "want not found")
+	})
+	t.Run("unknown network", func(t *testing.T) {
+		_, err := testResolver.LookupIP(ctx, "tcp", "example.com")
+		AssertErrorContains(t, err, "unknown network")
+	})
+}
+
+func TestHostsResolver(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "hosts")
+	err := os.WriteFile(p, []byte(`
+# comment
+127.0.0.1 localhost
+::1       localhost ip6-localhost # trailing comment
+192.0.2.1 Example.com www.example.com
+`), 0o644)
+	AssertNoError(t, err)
+
+	ctx := context.Background()
+	r := xddr.HostsResolver{Path: p}
+
+	ips, err := r.LookupIP(ctx, "ip", "localhost")
+	AssertNoError(t, err)
+	Assert(t, slices.Equal(ips, []xddr.IP{"127.0.0.1", "::1"}), "got %v", ips)
+
+	ips, err = r.LookupIP(ctx, "ip6", "localhost")
+	AssertNoError(t, err)
+	Assert(t, slices.Equal(ips, []xddr.IP{"::1"}), "got %v", ips)
+
+	ips, err = r.LookupIP(ctx, "ip", "www.example.com.")
+	AssertNoError(t, err)
+	Assert(t, slices.Equal(ips, []xddr.IP{"192.0.2.1"}), "got %v", ips)
+
+	_, err = r.LookupIP(ctx, "ip", "foo.example.com")
+	AssertErrorContains(t, err, "no such host")
+
+	// Invalid lines are skipped.
+	err = os.WriteFile(p, []byte(`
+not-an-ip foo.example.com
+192.0.2.2
+192.0.2.3 under_score.example.com foo.example.com
+192.0.2.4 bar.example.com
+`), 0o644)
+	AssertNoError(t, err)
+	ips, err = r.LookupIP(ctx, "ip", "foo.example.com")
+	AssertNoError(t, err)
+	Assert(t, slices.Equal(ips, []xddr.IP{"192.0.2.3"}), "got %v", ips)
+	ips, err = r.LookupIP(ctx, "ip", "bar.example.com")
+	AssertNoError(t, err)
+	Assert(t, slices.Equal(ips, []xddr.IP{"192.0.2.4"}), "got %v", ips)
+
+	_, err = xddr.HostsResolver{Path: filepath.Join(t.TempDir(), "missing")}.LookupIP(ctx, "ip", "localhost")
+	Assert(t, errors.Is(err, os.ErrNotExist), "want not exist error, got %v", err)
+}
+
+func TestResolveHostPort(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		given xddr.HostPort
+		want  []xddr.IPPort
+	}{
+		{"example.com:80", []xddr.IPPort{"192.0.2.1:80", "[2001:db8::1]:80"}},
+		{"127.0.0.1:80", []xddr.IPPort{"127.0.0.1:80"}},
+		{"[::1]:80", []xddr.IPPort{"[::1]:80"}},
+		{"[fe80::1%25eth0]:80", []xddr.IPPort{"[fe80::1%eth0]:80"}},
+	} {
+		t.Run(fmt.Sprintf("ResolveHostPort(%q)=%v", tc.given, tc.want), func(t *testing.T) {
+			vs, err := xddr.ResolveHostPort(ctx, testResolver, tc.given)
+			AssertNoError(t, err)
+			Assert(t, slices.Equal(vs, tc.want), "want %v, got %v", tc.want, vs)
+		})
+	}
+
+	_, err := xddr.ResolveHostPort(ctx, testResolver, "c.example.com:80")
+	AssertErrorContains(t, err, "no such host")
+}
+
+func TestResolveHTTP(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		given xddr.HTTP
+		want  []xddr.IPPort
+	}{
+		{"http://a.example.com", []xddr.IPPort{"192.0.2.10:80"}},
+		{"https://a.example.com/foo", []xddr.IPPort{"192.0.2.10:443"}},
+		{"http://a.example.com:8080", []xddr.IPPort{"192.0.2.10:8080"}},
+		{"https://[::1]", []xddr.IPPort{"[::1]:443"}},
+	} {
+		t.Run(fmt.Sprintf("ResolveHTTP(%q)=%v", tc.given, tc.want), func(t *testing.T) {
+			vs, err := xddr.ResolveHTTP(ctx, testResolver, tc.given)
+			AssertNoError(t, err)
+			Assert(t, slices.Equal(vs, tc.want), "want %v, got %v", tc.want, vs)
+		})
+	}
+}
+
+func TestResolveGRPC(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		given xddr.GRPC
+		want  []xddr.IPPort
+	}{
+		{"dns:///a.example.com:50051", []xddr.IPPort{"192.0.2.10:50051"}},
+		{"dns://8.8.8.8/a.example.com:50051", []xddr.IPPort{"192.0.2.10:50051"}},
+		{"dns:a.example.com", []xddr.IPPort{"192.0.2.10:443"}},
+		{"a.example.com:50051", []xddr.IPPort{"192.0.2.10:50051"}},
+		{"a.example.com", []xddr.IPPort{"192.0.2.10:443"}},
+		{"ipv4:198.51.100.1:50051,198.51.100.2", []xddr.IPPort{"198.51.100.1:50051", "198.51.100.2:443"}},
+		{"ipv6:[2001:db8::1]:50051,2001:db8::2", []xddr.IPPort{"[2001:db8::1]:50051", "[2001:db8::2]:443"}},
+	} {
+		t.Run(fmt.Sprintf("ResolveGRPC(%q)=%v", tc.given, tc.want), func(t *testing.T) {
+			vs, err := xddr.ResolveGRPC(ctx, testResolver, tc.given)
+			AssertNoError(t, err)
+			Assert(t, slices.Equal(vs, tc.want), "want %v, got %v", tc.want, vs)
+		})
+	}
+	for _, tc := range [][]string{
+		{"cannot be resolved into IP endpoints",
+			"unix:///run/app.sock",
+			"xds:///wallet.grpcwallet.io",
+		},
+		{"[1]: not an ipv4 address",
+			"ipv4:198.51.100.1,[::1]",
+		},
+		{"[0]: not an ipv6 address",
+			"ipv6:198.51.100.1",
+		},
+		{"invalid port",
+			"ipv4:198.51.100.1:http",
+		},
+		{"missing host",
+			"dns:///",
+		},
+	} {
+		for _, given := range tc[1:] {
+			t.Run(fmt.Sprintf("ResolveGRPC(%q) -> %q", given, tc[0]), func(t *testing.T) {
+				_, err := xddr.ResolveGRPC(ctx, testResolver, xddr.GRPC(given))
+				AssertErrorContains(t, err, tc[0])
+			})
+		}
+	}
+}
+
+func TestResolveSRV(t *testing.T) {
+	ctx := context.Background()
+
+	vs, err := xddr.ResolveSRV(ctx, testResolver, "_grpc._tcp.example.com")
+	AssertNoError(t, err)
+	want := []xddr.IPPort{"192.0.2.10:50051", "[2001:db8::b]:50052"}
+	Assert(t, slices.Equal(vs, want), "want %v, got %v", want, vs)
+
+	_, err = xddr.ResolveSRV(ctx, testResolver, "_none._tcp.example.com")
+	AssertErrorContains(t, err, "is not available")
+
+	_, err = xddr.ResolveSRV(ctx, testResolver, "_http._tcp.example.com")
+	AssertErrorContains(t, err, "no such host")
+}
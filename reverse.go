@@ -0,0 +1,163 @@
+package xddr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	reverseZoneIPv4 = "in-addr.arpa."
+	reverseZoneIPv6 = "ip6.arpa."
+)
+
+// ReverseName returns the domain name used for reverse DNS lookup (PTR record) of the address.
+//
+// Examples:
+//
+//	192.0.2.1   -> 1.2.0.192.in-addr.arpa.
+//	2001:db8::1 -> 1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.
+func (v IP) ReverseName() Domain {
+	if ipv4, ok := v.V4(); ok {
+		return ipv4.ReverseName()
+	}
+	if ipv6, ok := v.V6(); ok {
+		return ipv6.ReverseName()
+	}
+	return ""
+}
+
+func (v IPv4) ReverseName() Domain {
+	b := v.Bytes()
+	return reverseNameIPv4(b[:])
+}
+
+func (v IPv6) ReverseName() Domain {
+	b := v.Bytes()
+	return reverseNameIPv6(b[:], 32)
+}
+
+// ReverseZone returns the reverse DNS zone that covers the network.
+// Since a zone can only be delegated on an octet boundary for IPv4 and on a nibble boundary for IPv6,
+// the network size is rounded down to the nearest boundary so the returned zone
+// is the smallest one that encloses the network.
+// It returns an empty value if the network is invalid.
+//
+// Examples:
+//
+//	192.0.2.0/24   -> 2.0.192.in-addr.arpa.
+//	192.0.2.0/26   -> 2.0.192.in-addr.arpa.
+//	2001:db8::/32  -> 8.b.d.0.1.0.0.2.ip6.arpa.
+func (v IPwithCIDR) ReverseZone() Domain {
+	w, err := v.Sanitize()
+	if err != nil {
+		return ""
+	}
+
+	ip, n := w.Split()
+	if ipv4, ok := ip.V4(); ok {
+		b := ipv4.Bytes()
+		return reverseNameIPv4(b[:n/8])
+	}
+	if ipv6, ok := ip.V6(); ok {
+		b := ipv6.Bytes()
+		return reverseNameIPv6(b[:], n/4)
+	}
+	return ""
+}
+
+func reverseNameIPv4(b []byte) Domain {
+	var r strings.Builder
+	r.Grow(len("255.255.255.255.") + len(reverseZoneIPv4))
+	for i := len(b) - 1; i >= 0; i-- {
+		r.WriteString(strconv.Itoa(int(b[i])))
+		r.WriteByte('.')
+	}
+	r.WriteString(reverseZoneIPv4)
+	return Domain(r.String())
+}
+
+// reverseNameIPv6 builds nibble-form name of the first n nibbles of b.
+func reverseNameIPv6(b []byte, n int) Domain {
+	const hex = "0123456789abcdef"
+
+	var r strings.Builder
+	r.Grow(n*2 + len(reverseZoneIPv6))
+	for i := n - 1; i >= 0; i-- {
+		c := b[i/2]
+		if i%2 == 0 {
+			c >>= 4
+		}
+		r.WriteByte(hex[c&0x0f])
+		r.WriteByte('.')
+	}
+	r.WriteString(reverseZoneIPv6)
+	return Domain(r.String())
+}
+
+// ParseReverseName parses the domain name used for reverse DNS lookup into the IP address.
+// It is the inverse of [IP.ReverseName] and the trailing dot is optional.
+func ParseReverseName(v Domain) (IP, error) {
+	w, err := v.Sanitize()
+	if err != nil {
+		return "", err
+	}
+
+	s := strings.TrimSuffix(string(w), ".")
+	if rest, ok := strings.CutSuffix(s, "."+strings.TrimSuffix(reverseZoneIPv4, ".")); ok {
+		return parseReverseNameIPv4(rest)
+	}
+	if rest, ok := strings.CutSuffix(s, "."+strings.TrimSuffix(reverseZoneIPv6, ".")); ok {
+		return parseReverseNameIPv6(rest)
+	}
+	return "", errors.New("not a reverse DNS name")
+}
+
+func parseReverseNameIPv4(s string) (IP, error) {
+	es := strings.SplitN(s, ".", 5)
+	if len(es) != 4 {
+		return "", fmt.Errorf("must have 4 labels for IPv4 address")
+	}
+
+	var b [4]byte
+	for i, e := range es {
+		if len(e) > 1 && e[0] == '0' {
+			return "", errPosF(i, "leading zeros not allowed")
+		}
+		n, err := strconv.Atoi(e)
+		if err != nil {
+			return "", errPosF(i, "not a valid number")
+		}
+		if n < 0 || n > 255 {
+			return "", errPosF(i, "must be between 0 and 255, got %d", n)
+		}
+		b[3-i] = byte(n)
+	}
+	return IP(ipv4FromBytes(b)), nil
+}
+
+func parseReverseNameIPv6(s string) (IP, error) {
+	es := strings.SplitN(s, ".", 33)
+	if len(es) != 32 {
+		return "", fmt.Errorf("must have 32 labels for IPv6 address")
+	}
+
+	var b [16]byte
+	for i, e := range es {
+		if len(e) != 1 {
+			return "", errPosF(i, "must be a single hex digit")
+		}
+		n, ok := unhex(e[0])
+		if !ok {
+			return "", errPosF(i, "not a valid hex digit")
+		}
+
+		j := 31 - i
+		if j%2 == 0 {
+			n <<= 4
+		}
+		b[j/2] |= n
+	}
+	return IP(ipv6FromBytes(b)), nil
+}
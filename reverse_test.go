@@ -0,0 +1,107 @@
+package xddr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestReverseName(t *testing.T) {
+	for _, tc := range []struct {
+		given xddr.IP
+		want  xddr.Domain
+	}{
+		{"192.0.2.1", "1.2.0.192.in-addr.arpa."},
+		{"10.0.0.255", "255.0.0.10.in-addr.arpa."},
+		{"::1", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa."},
+		{"2001:db8::567:89ab", "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+		{"::ffff:192.0.2.1", "1.0.2.0.0.0.0.c.f.f.f.f.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa."},
+	} {
+		t.Run(fmt.Sprintf("IP(%q).ReverseName()=%q", tc.given, tc.want), func(t *testing.T) {
+			AssertEq(t, tc.given.ReverseName(), tc.want)
+
+			ip, err := xddr.ParseReverseName(tc.want)
+			AssertNoError(t, err)
+			AssertEq(t, ip, tc.given)
+		})
+	}
+}
+
+func TestReverseZone(t *testing.T) {
+	for _, tc := range []struct {
+		given xddr.IPwithCIDR
+		want  xddr.Domain
+	}{
+		{"0.0.0.0/0", "in-addr.arpa."},
+		{"10.0.0.0/8", "10.in-addr.arpa."},
+		{"192.0.2.0/24", "2.0.192.in-addr.arpa."},
+		{"192.0.2.64/26", "2.0.192.in-addr.arpa."},
+		{"172.16.0.0/12", "172.in-addr.arpa."},
+		{"192.0.2.1/32", "1.2.0.192.in-addr.arpa."},
+		{"2001:db8::/32", "8.b.d.0.1.0.0.2.ip6.arpa."},
+		{"2001:db8::/34", "8.b.d.0.1.0.0.2.ip6.arpa."},
+		{"2001:db8:1200::/40", "2.1.8.b.d.0.1.0.0.2.ip6.arpa."},
+		{"::/0", "ip6.arpa."},
+		{"2001:DB8::/32", "8.b.d.0.1.0.0.2.ip6.arpa."},
+
+		// Invalid networks.
+		{"192.0.2.0/33", ""},
+		{"192.0.2.0/-1", ""},
+		{"2001:db8::/129", ""},
+		{"foo/8", ""},
+	} {
+		t.Run(fmt.Sprintf("IPwithCIDR(%q).ReverseZone()=%q", tc.given, tc.want), func(t *testing.T) {
+			AssertEq(t, tc.given.ReverseZone(), tc.want)
+		})
+	}
+}
+
+func TestParseReverseName(t *testing.T) {
+	for _, tc := range []struct {
+		given xddr.Domain
+		want  xddr.IP
+	}{
+		{"1.2.0.192.in-addr.arpa", "192.0.2.1"},
+		{"1.2.0.192.IN-ADDR.ARPA.", "192.0.2.1"},
+		{"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.B.D.0.1.0.0.2.ip6.arpa", "2001:db8::1"},
+	} {
+		t.Run(fmt.Sprintf("ParseReverseName(%q)=%q", tc.given, tc.want), func(t *testing.T) {
+			ip, err := xddr.ParseReverseName(tc.given)
+			AssertNoError(t, err)
+			AssertEq(t, ip, tc.want)
+		})
+	}
+	for _, tc := range [][]string{
+		{"not a reverse DNS name",
+			"example.com",
+			"in-addr.arpa",
+		},
+		{"must have 4 labels",
+			"2.0.192.in-addr.arpa",
+			"1.1.2.0.192.in-addr.arpa",
+		},
+		{"leading zeros not allowed",
+			"01.2.0.192.in-addr.arpa",
+		},
+		{"must be between 0 and 255",
+			"256.2.0.192.in-addr.arpa",
+		},
+		{"must have 32 labels",
+			"8.b.d.0.1.0.0.2.ip6.arpa",
+		},
+		{"must be a single hex digit",
+			"10.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		},
+		{"not a valid hex digit",
+			"g.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa",
+		},
+	} {
+		for _, given := range tc[1:] {
+			t.Run(fmt.Sprintf("ParseReverseName(%q) -> %q", given, tc[0]), func(t *testing.T) {
+				_, err := xddr.ParseReverseName(xddr.Domain(given))
+				AssertErrorContains(t, err, tc[0])
+			})
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package xddr
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// SRVName represents an owner name of DNS SRV record (RFC 2782).
+// Service and protocol labels are prefixed with an underscore.
+//
+// Syntax:
+//
+//	_<service>._<proto>.<domain>
+//
+// Examples:
+//
+//	_grpc._tcp.example.com
+//	_sip._udp.example.com.
+type SRVName string
+
+// SRVNameOf composes the SRV name from the service, the protocol, and the domain.
+// Service and protocol can be given with or without the leading underscore.
+func SRVNameOf(service, proto string, domain Domain) (SRVName, error) {
+	service = "_" + strings.TrimPrefix(service, "_")
+	proto = "_" + strings.TrimPrefix(proto, "_")
+	return SRVName(service + "." + proto + "." + string(domain)).Sanitize()
+}
+
+func (v SRVName) Sanitize() (SRVName, error) {
+	s := string(v)
+	if s == "" {
+		return "", errors.New("SRV name cannot be empty")
+	}
+
+	service, rest, ok := strings.Cut(s, ".")
+	if !ok {
+		return "", errors.New("missing protocol label")
+	}
+	proto, domain, ok := strings.Cut(rest, ".")
+	if !ok || domain == "" {
+		return "", errors.New("missing domain")
+	}
+
+	pos := 0
+	w, err := sanitizeServiceLabel(service)
+	if err != nil {
+		return "", accPosErr(err, pos)
+	}
+	service = w
+	pos += len(service) + 1
+
+	w, err = sanitizeProtoLabel(proto)
+	if err != nil {
+		return "", accPosErr(err, pos)
+	}
+	proto = w
+	pos += len(proto) + 1
+
+	d, err := Domain(domain).SanitizeDNSName()
+	if err != nil {
+		return "", accPosErr(err, pos)
+	}
+
+	return SRVName(service + "." + proto + "." + string(d)), nil
+}
+
+// sanitizeServiceLabel validates the service name with a leading underscore
+// as described in RFC 6335 §5.1.
+func sanitizeServiceLabel(s string) (string, error) {
+	name, ok := strings.CutPrefix(s, "_")
+	if !ok {
+		return "", errPosF(0, "service label must start with '_'")
+	}
+	if name == "" {
+		return "", errPosF(1, "empty service name")
+	}
+	if len(name) > 15 {
+		return "", errPosF(1, "service name too long: must be at most 15 characters, got %d", len(name))
+	}
+
+	a := false // there is a letter
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case isAlpha(c):
+			a = true
+		case isDigit(c):
+		case c == '-':
+			if i == 0 || i == len(name)-1 {
+				return "", errPosF(1+i, "service name cannot start or end with a hyphen")
+			}
+			if name[i-1] == '-' {
+				return "", errPosF(1+i, "service name cannot have consecutive hyphens")
+			}
+		default:
+			return "", errPosF(1+i, "invalid character %q in service name", c)
+		}
+	}
+	if !a {
+		return "", errPosF(1, "service name must have at least one letter")
+	}
+
+	return strings.ToLower(s), nil
+}
+
+func sanitizeProtoLabel(s string) (string, error) {
+	name, ok := strings.CutPrefix(s, "_")
+	if !ok {
+		return "", errPosF(0, "protocol label must start with '_'")
+	}
+	if name == "" {
+		return "", errPosF(1, "empty protocol name")
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !isAlpha(c) && !isDigit(c) && c != '-' {
+			return "", errPosF(1+i, "invalid character %q in protocol name", c)
+		}
+	}
+
+	return strings.ToLower(s), nil
+}
+
+func (v SRVName) Split() (service, proto string, domain Domain) {
+	s := string(v)
+	service, s, _ = strings.Cut(s, ".")
+	proto, s, _ = strings.Cut(s, ".")
+	return strings.TrimPrefix(service, "_"), strings.TrimPrefix(proto, "_"), Domain(s)
+}
+
+// Service returns the service name without the leading underscore.
+func (v SRVName) Service() string {
+	w, _, _ := v.Split()
+	return w
+}
+
+// Proto returns the protocol name without the leading underscore.
+func (v SRVName) Proto() string {
+	_, w, _ := v.Split()
+	return w
+}
+
+func (v SRVName) Domain() Domain {
+	_, _, w := v.Split()
+	return w
+}
+
+// ServiceInstance represents a DNS-SD service instance name (RFC 6763 §4.1).
+// The instance label can contain any UTF-8 characters except ASCII control characters,
+// where dots and backslashes are escaped with a backslash.
+//
+// Syntax:
+//
+//	<instance>.<service>
+//
+// where <service> is a [SRVName].
+//
+// Examples:
+//
+//	My Printer._ipp._tcp.local.
+//	Joe\.s Printer._ipp._tcp.example.com
+type ServiceInstance string
+
+// ServiceInstanceOf composes the service instance name from the unescaped instance name and the service.
+func ServiceInstanceOf(instance string, service SRVName) (ServiceInstance, error) {
+	return ServiceInstance(escapeLabel(instance) + "." + string(service)).Sanitize()
+}
+
+func (v ServiceInstance) Sanitize() (ServiceInstance, error) {
+	s := string(v)
+	if s == "" {
+		return "", errors.New("service instance name cannot be empty")
+	}
+
+	label, rest, ok := cutEscapedLabel(s)
+	if !ok {
+		return "", errors.New("missing service")
+	}
+
+	instance, err := unescapeLabel(label)
+	if err != nil {
+		return "", err
+	}
+	if instance == "" {
+		return "", errPosF(0, "empty instance name")
+	}
+	if len(instance) > 63 {
+		return "", errPosF(0, "instance name too long: must be at most 63 octets, got %d", len(instance))
+	}
+	if !utf8.ValidString(instance) {
+		return "", errPosF(0, "instance name must be valid UTF-8")
+	}
+	for i := 0; i < len(instance); i++ {
+		if c := instance[i]; c < 0x20 || c == 0x7f {
+			return "", errPosF(0, "instance name cannot contain control character %q", c)
+		}
+	}
+
+	service, err := SRVName(rest).Sanitize()
+	if err != nil {
+		return "", accPosErr(err, len(label)+1)
+	}
+
+	return ServiceInstance(escapeLabel(instance) + "." + string(service)), nil
+}
+
+// Split returns the unescaped instance name and the service.
+func (v ServiceInstance) Split() (instance string, service SRVName) {
+	label, rest, _ := cutEscapedLabel(string(v))
+	instance, _ = unescapeLabel(label)
+	return instance, SRVName(rest)
+}
+
+// Instance returns the unescaped instance name.
+func (v ServiceInstance) Instance() string {
+	w, _ := v.Split()
+	return w
+}
+
+func (v ServiceInstance) Service() SRVName {
+	_, w := v.Split()
+	return w
+}
+
+// cutEscapedLabel slices s around the first dot which is not escaped by a backslash.
+func cutEscapedLabel(s string) (label, rest string, ok bool) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '.':
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+// unescapeLabel decodes "\X" and "\DDD" escapes of DNS presentation format (RFC 1035 §5.1).
+func unescapeLabel(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+
+	var r strings.Builder
+	r.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			r.WriteByte(c)
+			continue
+		}
+		if i+1 >= len(s) {
+			return "", errPosF(i, "incomplete escape")
+		}
+		if !isDigit(s[i+1]) {
+			r.WriteByte(s[i+1])
+			i++
+			continue
+		}
+		if i+3 >= len(s) || !isDigit(s[i+2]) || !isDigit(s[i+3]) {
+			return "", errPosF(i, "invalid decimal escape")
+		}
+		n := int(s[i+1]-'0')*100 + int(s[i+2]-'0')*10 + int(s[i+3]-'0')
+		if n > 255 {
+			return "", errPosF(i, "invalid decimal escape %q", s[i:i+4])
+		}
+		r.WriteByte(byte(n))
+		i += 3
+	}
+	return r.String(), nil
+}
+
+func escapeLabel(s string) string {
+	if !strings.ContainsAny(s, ".\\") {
+		return s
+	}
+
+	var r strings.Builder
+	r.Grow(len(s) + 2)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '.' || c == '\\' {
+			r.WriteByte('\\')
+		}
+		r.WriteByte(c)
+	}
+	return r.String()
+}
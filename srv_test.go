@@ -0,0 +1,165 @@
+package xddr_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestSRVName(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.SRVName
+			want  xddr.SRVName
+		}{
+			{"_grpc._tcp.example.com", "_grpc._tcp.example.com"},
+			{"_SIP._UDP.Example.COM.", "_sip._udp.example.com."},
+			{"_xmpp-server._tcp.example.com", "_xmpp-server._tcp.example.com"},
+			{"_ldap._tcp.dc._msdcs.example.com", "_ldap._tcp.dc._msdcs.example.com"},
+			{"_h3._udp.local", "_h3._udp.local"},
+		} {
+			t.Run(fmt.Sprintf("SRVName(%q).Sanitize()=%q", tc.given, tc.want), func(t *testing.T) {
+				v, err := tc.given.Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, tc.want)
+			})
+		}
+		for _, tc := range [][]string{
+			{"cannot be empty",
+				"",
+			},
+			{"missing protocol label",
+				"_grpc",
+			},
+			{"missing domain",
+				"_grpc._tcp",
+				"_grpc._tcp.",
+			},
+			{"[0]: service label must start with '_'",
+				"grpc._tcp.example.com",
+			},
+			{"[1]: empty service name",
+				"_._tcp.example.com",
+			},
+			{"[1]: service name too long",
+				"_abcdefghijklmnop._tcp.example.com",
+			},
+			{"service name cannot start or end with a hyphen",
+				"_-grpc._tcp.example.com",
+				"_grpc-._tcp.example.com",
+			},
+			{"[4]: service name cannot have consecutive hyphens",
+				"_ab--c._tcp.example.com",
+			},
+			{"[2]: invalid character",
+				"_a_b._tcp.example.com",
+			},
+			{"service name must have at least one letter",
+				"_123._tcp.example.com",
+			},
+			{"[6]: protocol label must start with '_'",
+				"_grpc.tcp.example.com",
+			},
+			{"[7]: empty protocol name",
+				"_grpc._.example.com",
+			},
+			{"[14]: invalid character",
+				"_grpc._tcp.exa mple.com",
+			},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("SRVName(%q).Sanitize() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.SRVName(given).Sanitize()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+	t.Run("SRVNameOf", func(t *testing.T) {
+		v, err := xddr.SRVNameOf("grpc", "tcp", "example.com")
+		AssertNoError(t, err)
+		AssertEq(t, v, "_grpc._tcp.example.com")
+
+		v, err = xddr.SRVNameOf("_sip", "_udp", "Example.com.")
+		AssertNoError(t, err)
+		AssertEq(t, v, "_sip._udp.example.com.")
+
+		_, err = xddr.SRVNameOf("grpc", "tcp", "")
+		AssertErrorContains(t, err, "missing domain")
+	})
+	t.Run("Split", func(t *testing.T) {
+		v := xddr.SRVName("_grpc._tcp.example.com.")
+		AssertEq(t, v.Service(), "grpc")
+		AssertEq(t, v.Proto(), "tcp")
+		AssertEq(t, v.Domain(), "example.com.")
+	})
+}
+
+func TestServiceInstance(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, tc := range []struct {
+			given xddr.ServiceInstance
+			want  xddr.ServiceInstance
+		}{
+			{"My Printer._ipp._tcp.local.", "My Printer._ipp._tcp.local."},
+			{`Joe\.s Printer._IPP._TCP.Example.com`, `Joe\.s Printer._ipp._tcp.example.com`},
+			{`a\\b._http._tcp.local`, `a\\b._http._tcp.local`},
+			{`Living\032Room._http._tcp.local`, `Living Room._http._tcp.local`},
+			{`\L\i\v\e._http._tcp.local`, `Live._http._tcp.local`},
+			{"Café._http._tcp.local", "Café._http._tcp.local"},
+		} {
+			t.Run(fmt.Sprintf("ServiceInstance(%q).Sanitize()=%q", tc.given, tc.want), func(t *testing.T) {
+				v, err := tc.given.Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, tc.want)
+			})
+		}
+		for _, tc := range [][]string{
+			{"cannot be empty",
+				"",
+			},
+			{"missing service",
+				"Printer",
+				`Printer\._ipp\._tcp\.local`,
+			},
+			{"empty instance name",
+				"._ipp._tcp.local",
+			},
+			{"instance name too long",
+				"0123456789012345678901234567890123456789012345678901234567890123._ipp._tcp.local",
+			},
+			{"must be valid UTF-8",
+				"\xff._ipp._tcp.local",
+			},
+			{"cannot contain control character",
+				"a\tb._ipp._tcp.local",
+				`a\009b._ipp._tcp.local`,
+			},
+			{"invalid decimal escape",
+				`a\09._ipp._tcp.local`,
+				`a\256._ipp._tcp.local`,
+			},
+			{"[8]: service label must start with '_'",
+				"Printer.ipp._tcp.local",
+			},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("ServiceInstance(%q).Sanitize() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.ServiceInstance(given).Sanitize()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+	t.Run("ServiceInstanceOf", func(t *testing.T) {
+		v, err := xddr.ServiceInstanceOf(`Joe's Printer v1.2 \o/`, "_ipp._tcp.local.")
+		AssertNoError(t, err)
+		AssertEq(t, v, `Joe's Printer v1\.2 \\o/._ipp._tcp.local.`)
+		AssertEq(t, v.Instance(), `Joe's Printer v1.2 \o/`)
+		AssertEq(t, v.Service(), "_ipp._tcp.local.")
+
+		_, err = xddr.ServiceInstanceOf("", "_ipp._tcp.local.")
+		AssertErrorContains(t, err, "empty instance name")
+	})
+}
@@ -0,0 +1,173 @@
+package xddr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"syscall"
+)
+
+// ErrAddressDenied is returned when the address is not allowed by [SSRFPolicy].
+var ErrAddressDenied = errors.New("address denied")
+
+// DefaultDenyClasses are classes of addresses denied by [SSRFPolicy] by default,
+// which are not reachable on the public Internet.
+var DefaultDenyClasses = []IPClass{
+	IPClassUnspecified,
+	IPClassThisNetwork,
+	IPClassLoopback,
+	IPClassPrivate,
+	IPClassShared,
+	IPClassLinkLocal,
+	IPClassProtocolAssignment,
+	IPClassDocumentation,
+	IPClassBenchmarking,
+	IPClassDiscardOnly,
+	IPClassUniqueLocal,
+	IPClassMulticast,
+	IPClassBroadcast,
+	IPClassReserved,
+}
+
+// SSRFPolicy decides whether outbound connections to addresses are allowed
+// to protect from Server-Side Request Forgery.
+//
+// Addresses which embed an IPv4 address, such as IPv4-mapped, IPv4-compatible,
+// NAT64 addresses with the Well-Known Prefix, 6to4, and Teredo addresses, are checked with the embedded addresses as well.
+//
+// Since the name can be resolved into another address when the connection is made (DNS rebinding),
+// use [SSRFPolicy.Control] to check the actual address to connect:
+//
+//	d := &net.Dialer{Control: policy.Control}
+type SSRFPolicy struct {
+	// Classes of addresses to deny.
+	// [DefaultDenyClasses] is used if nil.
+	DenyClasses []IPClass
+
+	// Networks to deny in addition to the classes.
+	Deny []IPwithCIDR
+
+	// Networks to allow even if they are denied by the classes or the networks.
+	Allow []IPwithCIDR
+
+	// Resolver to resolve domain names.
+	// [NetResolver] is used if nil.
+	Resolver Resolver
+}
+
+func (p *SSRFPolicy) denyClasses() []IPClass {
+	if p.DenyClasses == nil {
+		return DefaultDenyClasses
+	}
+	return p.DenyClasses
+}
+
+func (p *SSRFPolicy) resolver() Resolver {
+	if p.Resolver == nil {
+		return NetResolver{}
+	}
+	return p.Resolver
+}
+
+// CheckIP returns an error wrapping [ErrAddressDenied] if the address is not allowed.
+func (p *SSRFPolicy) CheckIP(ip IP) error {
+	w, err := ip.Sanitize()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAddressDenied, err)
+	}
+	ip = w
+
+	// The embedded address comes first as it tells more about where the connection goes.
+	ips := []IP{ip}
+	if ipv6, ok := ip.V6(); ok {
+		switch {
+		case ipv6.IsIPv4Mapped():
+			ips = []IP{ip.Unmap(), ip}
+		case ipv6.IsIPv4Compatible():
+			ipv4, _ := ipv6.ExtractIPv4(IPv4CompatiblePrefix)
+			ips = []IP{IP(ipv4), ip}
+		case ipv6.IsNAT64():
+			ipv4, _ := ipv6.ExtractIPv4(NAT64WellKnownPrefix)
+			ips = []IP{IP(ipv4), ip}
+		case ipv6.Is6to4():
+			ipv4, _ := ipv6.Extract6to4()
+			ips = []IP{IP(ipv4), ip}
+		case ipv6.IsTeredo():
+			server, client, _ := ipv6.ExtractTeredo()
+			ips = []IP{IP(client), IP(server), ip}
+		}
+	}
+
+	for _, ip := range ips {
+		if slices.ContainsFunc(p.Allow, func(n IPwithCIDR) bool { return n.Contains(ip) }) {
+			return nil
+		}
+	}
+	for _, ip := range ips {
+		if c := ip.Class(); slices.Contains(p.denyClasses(), c) {
+			return fmt.Errorf("%w: %s is %s", ErrAddressDenied, ip, c)
+		}
+		if i := slices.IndexFunc(p.Deny, func(n IPwithCIDR) bool { return n.Contains(ip) }); i >= 0 {
+			return fmt.Errorf("%w: %s is in %s", ErrAddressDenied, ip, p.Deny[i])
+		}
+	}
+	return nil
+}
+
+// CheckHost checks the address of the host.
+// A domain name is resolved and all of its addresses must be allowed.
+// A host in legacy IPv4 notation such as "0x7f.1" is checked as the address it denotes,
+// since it may be interpreted so by the HTTP client.
+func (p *SSRFPolicy) CheckHost(ctx context.Context, host Host) error {
+	if ip, ok := host.IP(); ok {
+		return p.CheckIP(ip)
+	}
+	if ipv4, ok := host.LegacyIPv4(); ok {
+		return p.CheckIP(IP(ipv4))
+	}
+
+	domain, ok := host.Domain()
+	if !ok {
+		return fmt.Errorf("%w: invalid host %q", ErrAddressDenied, host)
+	}
+
+	ips, err := p.resolver().LookupIP(ctx, "ip", domain)
+	if err != nil {
+		return err
+	}
+	if len(ips) == 0 {
+		// Nothing is checked, and the dialer may resolve the name into anything.
+		return fmt.Errorf("%w: %s has no addresses", ErrAddressDenied, domain)
+	}
+	for _, ip := range ips {
+		if err := p.CheckIP(ip); err != nil {
+			return fmt.Errorf("%s: %w", domain, err)
+		}
+	}
+	return nil
+}
+
+// CheckURL checks the host of the URL.
+// A URL of "http+unix" scheme is always denied since it connects to a local socket.
+func (p *SSRFPolicy) CheckURL(ctx context.Context, v HTTP) error {
+	w, err := v.Sanitize()
+	if err != nil {
+		return err
+	}
+	if path, ok := w.SocketPath(); ok {
+		return fmt.Errorf("%w: %s is a unix socket", ErrAddressDenied, path)
+	}
+	return p.CheckHost(ctx, URL(w).Host())
+}
+
+// Control checks the address right before the connection is made.
+// It can be used for the Control hook of [net.Dialer].
+func (p *SSRFPolicy) Control(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAddressDenied, err)
+	}
+	return p.CheckIP(IP(host))
+}
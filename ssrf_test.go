@@ -0,0 +1,169 @@
+package xddr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestIPwithCIDRContains(t *testing.T) {
+	for _, tc := range []struct {
+		network xddr.IPwithCIDR
+		ip      xddr.IP
+		want    bool
+	}{
+		{"10.0.0.0/8", "10.1.2.3", true},
+		{"10.0.0.0/8", "11.0.0.1", false},
+		{"192.0.2.64/26", "192.0.2.127", true},
+		{"192.0.2.64/26", "192.0.2.128", false},
+		{"0.0.0.0/0", "8.8.8.8", true},
+		{"0.0.0.0/0", "::1", false},
+		{"2001:db8::/32", "2001:db8:1::1", true},
+		{"2001:db8::/32", "2001:db9::1", false},
+		{"fe80::/10", "fe80::1%eth0", true},
+		{"::/0", "127.0.0.1", false},
+	} {
+		t.Run(fmt.Sprintf("IPwithCIDR(%q).Contains(%q)=%v", tc.network, tc.ip, tc.want), func(t *testing.T) {
+			AssertEq(t, tc.network.Contains(tc.ip), tc.want)
+		})
+	}
+}
+
+func TestSSRFPolicy(t *testing.T) {
+	ctx := context.Background()
+	p := &xddr.SSRFPolicy{
+		Deny:  []xddr.IPwithCIDR{"45.33.0.0/16"},
+		Allow: []xddr.IPwithCIDR{"10.0.1.0/24"},
+		Resolver: xddr.StaticResolver{
+			Hosts: map[xddr.Domain][]xddr.IP{
+				"public.example.com":   {"93.184.216.34", "2606:2800:220:1::1"},
+				"internal.example.com": {"10.0.0.1"},
+				"allowed.example.com":  {"10.0.1.1"},
+				"mixed.example.com":    {"93.184.216.34", "127.0.0.1"},
+				"empty.example.com":    {},
+			},
+		},
+	}
+
+	t.Run("CheckIP", func(t *testing.T) {
+		for _, given := range []xddr.IP{
+			"93.184.216.34",
+			"2606:2800:220:1::1",
+			"10.0.1.1",
+			"::ffff:10.0.1.1",
+			"64:ff9b::5db8:d822",
+			"2002:5db8:d822::1",
+			"2001:0:5db8:d822::a247:27dd",
+		} {
+			t.Run(fmt.Sprintf("CheckIP(%q) -> allowed", given), func(t *testing.T) {
+				AssertNoError(t, p.CheckIP(given))
+			})
+		}
+		for _, tc := range [][]string{
+			{"is loopback",
+				"127.0.0.1",
+				"::1",
+				"::ffff:127.0.0.1",
+				"::127.0.0.1",
+				"64:ff9b::7f00:1",
+				"2002:7f00:1::",
+				"2001:0:5db8:d822::80ff:fffe",
+			},
+			{"is private",
+				"10.0.0.1",
+				"192.168.1.1",
+				"2002:c0a8:101::1:2",
+				"2001:0:c0a8:101::a247:27dd",
+			},
+			{"is link-local",
+				"169.254.169.254",
+				"2001:0:5db8:d822::5601:5601",
+				"2002:a9fe:a9fe::",
+				"fe80::1%eth0",
+			},
+			{"is unspecified",
+				"0.0.0.0",
+				"::",
+			},
+			{"is unique-local",
+				"fd00::1",
+			},
+			{"is in 45.33.0.0/16",
+				"45.33.32.156",
+			},
+			{"address denied",
+				"not an IP",
+			},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("CheckIP(%q) -> %q", given, tc[0]), func(t *testing.T) {
+					err := p.CheckIP(xddr.IP(given))
+					Assert(t, errors.Is(err, xddr.ErrAddressDenied), "want denied, got %v", err)
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+	t.Run("CheckURL", func(t *testing.T) {
+		for _, given := range []xddr.HTTP{
+			"https://public.example.com/",
+			"https://allowed.example.com/",
+			"http://93.184.216.34:8080/foo",
+			"http://[2606:2800:220:1::1]/",
+		} {
+			t.Run(fmt.Sprintf("CheckURL(%q) -> allowed", given), func(t *testing.T) {
+				AssertNoError(t, p.CheckURL(ctx, given))
+			})
+		}
+		for _, given := range []xddr.HTTP{
+			"http://internal.example.com/",
+			"http://mixed.example.com/",
+			"http://127.0.0.1/",
+			"http://[::1]:8080/",
+			"http://[fe80::1%25eth0]/",
+			"http://0x7f.1/",
+			"http://2130706433/",
+			"http://0177.0.0.1/",
+			"http://empty.example.com/",
+			"http+unix://%2Fvar%2Frun%2Fdocker.sock/v1.41/info",
+			"http+unix://%40app/",
+		} {
+			t.Run(fmt.Sprintf("CheckURL(%q) -> denied", given), func(t *testing.T) {
+				err := p.CheckURL(ctx, given)
+				Assert(t, errors.Is(err, xddr.ErrAddressDenied), "want denied, got %v", err)
+			})
+		}
+
+		err := p.CheckURL(ctx, "http://unknown.example.com/")
+		AssertErrorContains(t, err, "no such host")
+	})
+	t.Run("DenyClasses", func(t *testing.T) {
+		p := &xddr.SSRFPolicy{DenyClasses: []xddr.IPClass{}}
+		AssertNoError(t, p.CheckIP("127.0.0.1"))
+
+		p = &xddr.SSRFPolicy{DenyClasses: []xddr.IPClass{xddr.IPClassLoopback}}
+		AssertNoError(t, p.CheckIP("10.0.0.1"))
+		AssertErrorContains(t, p.CheckIP("127.0.0.1"), "is loopback")
+	})
+	t.Run("Control", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		AssertNoError(t, err)
+		defer l.Close()
+
+		// The name may be resolved into a public address when it is checked
+		// but into the loopback when the connection is made.
+		d := &xddr.Dialer{
+			Resolver: xddr.StaticResolver{Hosts: map[xddr.Domain][]xddr.IP{"rebind.test": {"127.0.0.1"}}},
+			Dialer:   &net.Dialer{Control: p.Control},
+		}
+		_, err = xddr.DialWith(ctx, d, xddr.HostPort(fmt.Sprintf("rebind.test:%d", l.Addr().(*net.TCPAddr).Port)))
+		Assert(t, errors.Is(err, xddr.ErrAddressDenied), "want denied, got %v", err)
+
+		AssertNoError(t, p.Control("tcp", "93.184.216.34:443", nil))
+		AssertErrorContains(t, p.Control("tcp", "[fe80::1%eth0]:443", nil), "is link-local")
+	})
+}
@@ -3,6 +3,7 @@ package xddr
 import (
 	"errors"
 	"fmt"
+	"strconv"
 )
 
 type TCPLocal string
@@ -89,7 +90,10 @@ func (v TCPUDPLocal) WithHost(host string) (TCPUDPLocal, error) {
 		net = net[:3] + ":"
 	}
 
-	return TCPUDPLocal(net + string(a)), nil
+	if p := a.Port(); p >= 0 {
+		return TCPUDPLocal(net + string(h.raw()) + ":" + strconv.Itoa(p)), nil
+	}
+	return TCPUDPLocal(net + string(h.raw())), nil
 }
 
 func (v TCPUDPLocal) WithPort(port int) (TCPUDPLocal, error) {
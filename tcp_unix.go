@@ -1,8 +1,11 @@
 package xddr
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+)
 
-// TCPLocal or UnixLocal
+// TCPLocal, UnixLocal, FDLocal, VsockLocal, or MemLocal
 type TCPUnixLocal string
 
 func (v TCPUnixLocal) Sanitize() (TCPUnixLocal, error) {
@@ -19,12 +22,12 @@ func (v TCPUnixLocal) Sanitize() (TCPUnixLocal, error) {
 		}
 		return TCPUnixLocal(w), nil
 
-	case '.', '/':
+	case '.', '/', '@':
 		w, err := UnixLocal(s).Sanitize()
 		if err != nil {
 			return "", err
 		}
-		return TCPUnixLocal("unix:" + w), nil
+		return TCPUnixLocal(w), nil
 	}
 
 	net, _ := Local(v).Split()
@@ -36,13 +39,34 @@ func (v TCPUnixLocal) Sanitize() (TCPUnixLocal, error) {
 		}
 		return TCPUnixLocal(w), nil
 
-	case "unix":
+	case "unix", "unix-abstract":
 		w, err := UnixLocal(v).Sanitize()
 		if err != nil {
 			return "", err
 		}
 		return TCPUnixLocal(w), nil
 
+	case "fd", "systemd":
+		w, err := FDLocal(v).Sanitize()
+		if err != nil {
+			return "", err
+		}
+		return TCPUnixLocal(w), nil
+
+	case "vsock":
+		w, err := VsockLocal(v).Sanitize()
+		if err != nil {
+			return "", err
+		}
+		return TCPUnixLocal(w), nil
+
+	case "mem":
+		w, err := MemLocal(v).Sanitize()
+		if err != nil {
+			return "", err
+		}
+		return TCPUnixLocal(w), nil
+
 	default:
 		// "<host>:<port>"?
 		w, err := TCPLocal(s).Sanitize()
@@ -57,7 +81,7 @@ func (v TCPUnixLocal) WithHost(host string) (TCPUnixLocal, error) {
 	if host == "" {
 		return "", errors.New("host is empty")
 	}
-	if host[0] == '.' || host[0] == '/' {
+	if host[0] == '.' || host[0] == '/' || host[0] == '@' {
 		return TCPUnixLocal("unix:" + host), nil
 	}
 
@@ -83,7 +107,10 @@ func (v TCPUnixLocal) WithHost(host string) (TCPUnixLocal, error) {
 		net = "tcp:"
 	}
 
-	return TCPUnixLocal(net + string(a)), nil
+	if p := a.Port(); p >= 0 {
+		return TCPUnixLocal(net + string(h.raw()) + ":" + strconv.Itoa(p)), nil
+	}
+	return TCPUnixLocal(net + string(h.raw())), nil
 }
 
 func (v TCPUnixLocal) WithPort(port int) (TCPUnixLocal, error) {
@@ -443,6 +443,49 @@ func percent_decode(s string) (b byte, rest string, err error) {
 	return
 }
 
+// percent_decode_all decodes every percent-encoded octet in s.
+func percent_decode_all(s string) (string, error) {
+	if !strings.Contains(s, "%") {
+		return s, nil
+	}
+
+	var r strings.Builder
+	r.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			r.WriteByte(s[i])
+			continue
+		}
+
+		b, _, err := percent_decode(s[i:])
+		if err != nil {
+			return "", errPos(i, err)
+		}
+		r.WriteByte(b)
+		i += 2
+	}
+	return r.String(), nil
+}
+
+// percent_encode encodes every octet in s which does not pass the test.
+func percent_encode(s string, test func(byte) bool) string {
+	const hex = "0123456789ABCDEF"
+
+	var r strings.Builder
+	r.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if test(c) {
+			r.WriteByte(c)
+			continue
+		}
+		r.WriteByte('%')
+		r.WriteByte(hex[c>>4])
+		r.WriteByte(hex[c&0x0f])
+	}
+	return r.String()
+}
+
 type URLLike interface {
 	~string
 	_urlLike()
@@ -0,0 +1,105 @@
+package xddr
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// VsockLocal represents a local address of AF_VSOCK socket,
+// which connects virtual machines and their host.
+// An empty CID means any CID (VMADDR_CID_ANY) on listen and the local CID (VMADDR_CID_LOCAL) on dial
+// as an empty host of [TCPLocal] does.
+// Port 0 on listen chooses an available port.
+//
+// Syntax:
+//
+//	vsock:[<cid>]:<port>
+//
+// Examples:
+//
+//	vsock:3:1024
+//	vsock:2:8080
+//	vsock::1024
+type VsockLocal string
+
+func (v VsockLocal) _localLike() {}
+
+const (
+	vsockCIDAny   = 0xFFFFFFFF // VMADDR_CID_ANY
+	vsockCIDLocal = 1          // VMADDR_CID_LOCAL
+	vsockPortAny  = 0xFFFFFFFF // VMADDR_PORT_ANY
+)
+
+func (v VsockLocal) Sanitize() (VsockLocal, error) {
+	s := string(v)
+	if s == "" {
+		return "", errors.New("empty local address")
+	}
+
+	net, addr, ok := strings.Cut(s, ":")
+	if !ok || net != "vsock" {
+		return "", errors.New(`network must be "vsock"`)
+	}
+
+	a, err := parseVsockAddr(addr)
+	if err != nil {
+		return "", accPosErr(err, len(net)+1)
+	}
+	return VsockLocal(net + ":" + a.String()), nil
+}
+
+// CID returns the context ID of the address, or VMADDR_CID_ANY if it is empty.
+func (v VsockLocal) CID() uint32 {
+	a, _ := parseVsockAddr(Local(v).Address())
+	return a.cid
+}
+
+func (v VsockLocal) Port() uint32 {
+	a, _ := parseVsockAddr(Local(v).Address())
+	return a.port
+}
+
+// vsockAddr is an address of AF_VSOCK socket which implements [net.Addr].
+type vsockAddr struct {
+	cid  uint32
+	port uint32
+}
+
+func (a vsockAddr) Network() string {
+	return "vsock"
+}
+
+func (a vsockAddr) String() string {
+	cid := ""
+	if a.cid != vsockCIDAny {
+		cid = strconv.FormatUint(uint64(a.cid), 10)
+	}
+	return cid + ":" + strconv.FormatUint(uint64(a.port), 10)
+}
+
+func parseVsockAddr(addr string) (vsockAddr, error) {
+	c, p, ok := strings.Cut(addr, ":")
+	if !ok {
+		return vsockAddr{}, errPosF(len(addr), "missing port")
+	}
+
+	a := vsockAddr{cid: vsockCIDAny}
+	if c != "" {
+		cid, err := strconv.ParseUint(c, 10, 32)
+		if err != nil || cid == vsockCIDAny {
+			return vsockAddr{}, errPosF(0, "invalid CID")
+		}
+		a.cid = uint32(cid)
+	}
+	if p == "" {
+		return vsockAddr{}, errPosF(len(c)+1, "missing port")
+	}
+	port, err := strconv.ParseUint(p, 10, 32)
+	if err != nil || port == vsockPortAny {
+		return vsockAddr{}, errPosF(len(c)+1, "invalid port")
+	}
+	a.port = uint32(port)
+
+	return a, nil
+}
@@ -0,0 +1,285 @@
+//go:build linux && !386
+
+package xddr
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// Package syscall does not support AF_VSOCK, so sockets are handled by raw system calls.
+
+const afVsock = 40
+
+// rawSockaddrVM is struct sockaddr_vm.
+type rawSockaddrVM struct {
+	Family    uint16
+	Reserved1 uint16
+	Port      uint32
+	CID       uint32
+	Flags     uint8
+	Zero      [3]uint8
+}
+
+func errnoErr(e syscall.Errno) error {
+	if e == 0 {
+		return nil
+	}
+	return e
+}
+
+func vsockSocket() (*os.File, syscall.RawConn, error) {
+	fd, err := syscall.Socket(afVsock, syscall.SOCK_STREAM|syscall.SOCK_NONBLOCK|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, os.NewSyscallError("socket", err)
+	}
+
+	f := os.NewFile(uintptr(fd), "vsock")
+	rc, err := f.SyscallConn()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, rc, nil
+}
+
+// vsockCall calls bind or connect with the address.
+func vsockCall(trap uintptr, fd uintptr, a vsockAddr) error {
+	sa := rawSockaddrVM{Family: afVsock, Port: a.port, CID: a.cid}
+	_, _, e := syscall.Syscall(trap, fd, uintptr(unsafe.Pointer(&sa)), unsafe.Sizeof(sa))
+	return errnoErr(e)
+}
+
+// vsockName calls getsockname or getpeername.
+func vsockName(trap uintptr, fd uintptr) (vsockAddr, error) {
+	var sa rawSockaddrVM
+	n := uint32(unsafe.Sizeof(sa))
+	_, _, e := syscall.Syscall(trap, fd, uintptr(unsafe.Pointer(&sa)), uintptr(unsafe.Pointer(&n)))
+	if e != 0 {
+		return vsockAddr{}, e
+	}
+	return vsockAddr{sa.CID, sa.Port}, nil
+}
+
+func listenVsock(ctx context.Context, a vsockAddr) (net.Listener, error) {
+	f, rc, err := vsockSocket()
+	if err != nil {
+		return nil, err
+	}
+	if a.port == 0 {
+		a.port = vsockPortAny
+	}
+
+	var local vsockAddr
+	var serr error
+	if err := rc.Control(func(fd uintptr) {
+		if serr = vsockCall(syscall.SYS_BIND, fd, a); serr != nil {
+			serr = os.NewSyscallError("bind", serr)
+			return
+		}
+		if serr = syscall.Listen(int(fd), syscall.SOMAXCONN); serr != nil {
+			serr = os.NewSyscallError("listen", serr)
+			return
+		}
+		local, serr = vsockName(syscall.SYS_GETSOCKNAME, fd)
+	}); err != nil {
+		serr = err
+	}
+	if serr != nil {
+		f.Close()
+		return nil, serr
+	}
+
+	return &vsockListener{f: f, rc: rc, addr: local}, nil
+}
+
+type vsockListener struct {
+	f    *os.File
+	rc   syscall.RawConn
+	addr vsockAddr
+
+	closed atomic.Bool
+}
+
+func (l *vsockListener) Accept() (net.Conn, error) {
+	var nfd int
+	var remote vsockAddr
+	var serr error
+	err := l.rc.Read(func(fd uintptr) bool {
+		for {
+			var sa rawSockaddrVM
+			n := uint32(unsafe.Sizeof(sa))
+			r, _, e := syscall.Syscall6(syscall.SYS_ACCEPT4, fd,
+				uintptr(unsafe.Pointer(&sa)), uintptr(unsafe.Pointer(&n)),
+				syscall.SOCK_NONBLOCK|syscall.SOCK_CLOEXEC, 0, 0)
+			switch e {
+			case 0:
+				nfd = int(r)
+				remote = vsockAddr{sa.CID, sa.Port}
+				return true
+			case syscall.EAGAIN:
+				return false
+			case syscall.EINTR, syscall.ECONNABORTED:
+				continue
+			default:
+				serr = os.NewSyscallError("accept4", e)
+				return true
+			}
+		}
+	})
+	if err == nil {
+		err = serr
+	}
+	if err != nil {
+		if l.closed.Load() {
+			err = net.ErrClosed
+		}
+		return nil, &net.OpError{Op: "accept", Net: "vsock", Addr: l.addr, Err: err}
+	}
+
+	c, err := newVsockConn(nfd, remote)
+	if err != nil {
+		return nil, &net.OpError{Op: "accept", Net: "vsock", Addr: l.addr, Err: err}
+	}
+	return c, nil
+}
+
+func (l *vsockListener) Close() error {
+	l.closed.Store(true)
+	if err := l.f.Close(); err != nil {
+		return &net.OpError{Op: "close", Net: "vsock", Addr: l.addr, Err: err}
+	}
+	return nil
+}
+
+func (l *vsockListener) Addr() net.Addr {
+	return l.addr
+}
+
+func dialVsock(ctx context.Context, a vsockAddr) (net.Conn, error) {
+	f, rc, err := vsockSocket()
+	if err != nil {
+		return nil, err
+	}
+
+	var serr error
+	if err := rc.Control(func(fd uintptr) {
+		serr = vsockCall(syscall.SYS_CONNECT, fd, a)
+	}); err != nil {
+		serr = err
+	}
+	if serr == syscall.EINPROGRESS {
+		serr = waitConnect(ctx, f, rc)
+	}
+	if serr != nil {
+		f.Close()
+		return nil, serr
+	}
+
+	c := &vsockConn{f: f, remote: a}
+	if err := rc.Control(func(fd uintptr) {
+		c.local, serr = vsockName(syscall.SYS_GETSOCKNAME, fd)
+	}); err != nil {
+		serr = err
+	}
+	if serr != nil {
+		f.Close()
+		return nil, os.NewSyscallError("getsockname", serr)
+	}
+	return c, nil
+}
+
+// waitConnect waits for the non-blocking connect to be completed.
+func waitConnect(ctx context.Context, f *os.File, rc syscall.RawConn) error {
+	if d, ok := ctx.Deadline(); ok {
+		f.SetWriteDeadline(d)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		f.SetWriteDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	var serr error
+	err := rc.Write(func(fd uintptr) bool {
+		v, err := syscall.GetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_ERROR)
+		if err != nil {
+			serr = os.NewSyscallError("getsockopt", err)
+			return true
+		}
+		switch e := syscall.Errno(v); e {
+		case syscall.EINPROGRESS, syscall.EALREADY, syscall.EINTR:
+			return false
+		case 0:
+			// The socket may not be writable yet.
+			if _, err := vsockName(syscall.SYS_GETPEERNAME, fd); err == syscall.ENOTCONN {
+				return false
+			}
+			return true
+		default:
+			serr = os.NewSyscallError("connect", e)
+			return true
+		}
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	if serr != nil {
+		return serr
+	}
+	return f.SetWriteDeadline(time.Time{})
+}
+
+func newVsockConn(fd int, remote vsockAddr) (*vsockConn, error) {
+	local, err := vsockName(syscall.SYS_GETSOCKNAME, uintptr(fd))
+	if err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("getsockname", err)
+	}
+	return &vsockConn{f: os.NewFile(uintptr(fd), "vsock"), local: local, remote: remote}, nil
+}
+
+type vsockConn struct {
+	f      *os.File
+	local  vsockAddr
+	remote vsockAddr
+}
+
+func (c *vsockConn) Read(b []byte) (int, error) {
+	return c.f.Read(b)
+}
+
+func (c *vsockConn) Write(b []byte) (int, error) {
+	return c.f.Write(b)
+}
+
+func (c *vsockConn) Close() error {
+	return c.f.Close()
+}
+
+func (c *vsockConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *vsockConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+func (c *vsockConn) SetDeadline(t time.Time) error {
+	return c.f.SetDeadline(t)
+}
+
+func (c *vsockConn) SetReadDeadline(t time.Time) error {
+	return c.f.SetReadDeadline(t)
+}
+
+func (c *vsockConn) SetWriteDeadline(t time.Time) error {
+	return c.f.SetWriteDeadline(t)
+}
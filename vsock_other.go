@@ -0,0 +1,19 @@
+//go:build !linux || 386
+
+package xddr
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+var errVsockNotSupported = errors.New("vsock is not supported on this platform")
+
+func listenVsock(ctx context.Context, a vsockAddr) (net.Listener, error) {
+	return nil, errVsockNotSupported
+}
+
+func dialVsock(ctx context.Context, a vsockAddr) (net.Conn, error) {
+	return nil, errVsockNotSupported
+}
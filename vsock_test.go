@@ -0,0 +1,132 @@
+package xddr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lesomnus/xddr"
+)
+
+func TestVsockLocal(t *testing.T) {
+	t.Run("Sanitize", func(t *testing.T) {
+		for _, tc := range [][]string{
+			{"vsock:3:1024", "vsock:3:1024"},
+			{"vsock:03:01024", "vsock:3:1024"},
+			{"vsock::1024", "vsock::1024"},
+			{"vsock:2:0", "vsock:2:0"},
+		} {
+			t.Run(fmt.Sprintf("VsockLocal(%q).Sanitize()=%q", tc[0], tc[1]), func(t *testing.T) {
+				v, err := xddr.VsockLocal(tc[0]).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, v, xddr.VsockLocal(tc[1]))
+
+				w, err := xddr.TCPUnixLocal(tc[0]).Sanitize()
+				AssertNoError(t, err)
+				AssertEq(t, w, xddr.TCPUnixLocal(tc[1]))
+			})
+		}
+		for _, tc := range [][]string{
+			{"empty local address", ""},
+			{`network must be "vsock"`, "tcp:3:1024"},
+			{"[7]: missing port", "vsock:3"},
+			{"[8]: missing port", "vsock:3:"},
+			{"[6]: invalid CID", "vsock:x:1024", "vsock:4294967295:1024", "vsock:-1:1024"},
+			{"[8]: invalid port", "vsock:3:x", "vsock:3:4294967295", "vsock:3:4294967296"},
+		} {
+			for _, given := range tc[1:] {
+				t.Run(fmt.Sprintf("VsockLocal(%q).Sanitize() -> %q", given, tc[0]), func(t *testing.T) {
+					_, err := xddr.VsockLocal(given).Sanitize()
+					AssertErrorContains(t, err, tc[0])
+				})
+			}
+		}
+	})
+	t.Run("Split", func(t *testing.T) {
+		v := xddr.VsockLocal("vsock:3:1024")
+		AssertEq(t, v.CID(), 3)
+		AssertEq(t, v.Port(), 1024)
+		AssertEq(t, xddr.VsockLocal("vsock::1024").CID(), 0xFFFFFFFF)
+	})
+	t.Run("GRPC", func(t *testing.T) {
+		AssertEq(t, xddr.GRPC("vsock:3:1024").Local(), "vsock:3:1024")
+		AssertEq(t, xddr.GRPCLocal("vsock:3:1024").AsURL(), "vsock:3:1024")
+		AssertEq(t, xddr.GRPCLocal("vsock::1024").AsURL(), "vsock:1:1024")
+		AssertEq(t, xddr.HTTPLocal("vsock:3:1024").AsURL(), "")
+
+		v, err := xddr.GRPCLocal("vsock:3:1024").Sanitize()
+		AssertNoError(t, err)
+		AssertEq(t, v, "vsock:3:1024")
+	})
+	t.Run("Listen", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			_, err := xddr.Listen(xddr.VsockLocal("vsock::1024"))
+			AssertErrorContains(t, err, "not supported")
+			return
+		}
+
+		l, err := xddr.Listen(xddr.VsockLocal("vsock::0"))
+		if errors.Is(err, syscall.EAFNOSUPPORT) {
+			t.Skip("vsock is not available")
+		}
+		AssertNoError(t, err)
+		defer l.Close()
+		AssertEq(t, l.Addr().Network(), "vsock")
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := l.Accept()
+			done <- err
+		}()
+		l.Close()
+		select {
+		case err := <-done:
+			Assert(t, errors.Is(err, net.ErrClosed), "want net.ErrClosed, got %v", err)
+		case <-time.After(time.Second):
+			t.Fatal("accept is not unblocked by close")
+		}
+
+		// Nobody listens on the port.
+		d := &xddr.Dialer{Dialer: &net.Dialer{Timeout: 200 * time.Millisecond}}
+		_, err = xddr.DialWith(context.Background(), d, xddr.VsockLocal("vsock:1"+l.Addr().String()))
+		Assert(t, err != nil, "want error")
+	})
+	t.Run("Loopback", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("not supported")
+		}
+
+		// Loopback CID 1 requires vsock_loopback module.
+		l, err := xddr.Listen(xddr.VsockLocal("vsock:1:0"))
+		if err != nil {
+			t.Skipf("vsock loopback is not available: %s", err)
+		}
+		defer l.Close()
+
+		go func() {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			conn.Write([]byte("hello"))
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := xddr.Dial(ctx, xddr.GRPC("vsock:"+l.Addr().String()))
+		AssertNoError(t, err)
+		defer conn.Close()
+		AssertEq(t, conn.RemoteAddr().String(), l.Addr().String())
+
+		b, err := io.ReadAll(conn)
+		AssertNoError(t, err)
+		AssertEq(t, string(b), "hello")
+	})
+}